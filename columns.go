@@ -1,47 +1,141 @@
 package fbx
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"reflect"
 	"strings"
+	"time"
 	"unicode"
 )
 
 type Column struct {
-	Name         string
-	Domain       string
-	SqlType      string
-	SqlSubtype   sql.NullInt64
-	Length       int16 // DisplaySize
-	Precision    sql.NullInt64
-	Scale        int16
-	Default      sql.NullString
-	Nullable     sql.NullBool
-	TypeCode     int
-	InternalSize int
+	Name            string
+	Domain          string
+	SqlType         string
+	SqlSubtype      sql.NullInt64
+	Length          int16 // DisplaySize
+	Precision       sql.NullInt64
+	Scale           int16
+	Default         sql.NullString
+	Nullable        sql.NullBool   // RDB$NULL_FLAG: true for NOT NULL columns
+	TypeCode        int            // RDB$FIELD_TYPE
+	InternalSize    int            // bytes in a message buffer, including a VARCHAR's length prefix
+	CharacterSet    sql.NullString // also set for text blobs
+	Collation       sql.NullString
+	CharacterLength sql.NullInt64    // Length is in bytes
+	SegmentSize     sql.NullInt64    // blobs only
+	Dimensions      []ArrayDimension // array columns only
+	Computed        sql.NullString   // COMPUTED BY expression
+	Identity        string           // ALWAYS or BY DEFAULT for identity columns (Firebird 3+)
+	Generator       string           // sequence backing an identity column
 }
 
-func Columns(db *sql.DB, tableName string) (columns []*Column, err error) {
+// Insertable reports whether an INSERT may supply a value for the column,
+// which is not the case for computed and GENERATED ALWAYS identity columns.
+func (c *Column) Insertable() bool {
+	return !c.Computed.Valid && c.Identity != "ALWAYS"
+}
+
+func Columns(db Querier, tableName string) (columns []*Column, err error) {
+	return ColumnsContext(context.Background(), db, tableName)
+}
+
+func ColumnsContext(ctx context.Context, db Querier, tableName string) (columns []*Column, err error) {
+	relations, err := queryColumns(ctx, db, "WHERE r.rdb$relation_name = ?", tableName)
+	if err != nil {
+		return
+	}
+	return relations[tableName], nil
+}
+
+// AllColumns returns the columns of every user table and view, keyed by
+// relation name.
+func AllColumns(db Querier) (relations map[string][]*Column, err error) {
+	return AllColumnsContext(context.Background(), db)
+}
+
+func AllColumnsContext(ctx context.Context, db Querier) (relations map[string][]*Column, err error) {
+	return queryColumns(ctx, db, "WHERE r.rdb$system_flag <> 1 OR r.rdb$system_flag IS NULL")
+}
+
+// maxInList is the most items an IN list may hold before Firebird 5.
+const maxInList = 1500
+
+// ColumnsOfRelations returns the columns of the named relations, keyed by
+// relation name. Long lists of names are queried in batches.
+func ColumnsOfRelations(db Querier, relationNames ...string) (relations map[string][]*Column, err error) {
+	return ColumnsOfRelationsContext(context.Background(), db, relationNames...)
+}
+
+func ColumnsOfRelationsContext(ctx context.Context, db Querier, relationNames ...string) (relations map[string][]*Column, err error) {
+	relations = make(map[string][]*Column)
+	for len(relationNames) > 0 {
+		batch := relationNames
+		if len(batch) > maxInList {
+			batch = batch[:maxInList]
+		}
+		relationNames = relationNames[len(batch):]
+
+		args := make([]interface{}, len(batch))
+		for i, name := range batch {
+			args[i] = name
+		}
+		where := "WHERE r.rdb$relation_name IN (?" + strings.Repeat(", ?", len(args)-1) + ")"
+		var found map[string][]*Column
+		if found, err = queryColumns(ctx, db, where, args...); err != nil {
+			return nil, err
+		}
+		for name, cols := range found {
+			relations[name] = cols
+		}
+	}
+	return
+}
+
+func queryColumns(ctx context.Context, db Querier, where string, args ...interface{}) (relations map[string][]*Column, err error) {
 	const query = `
-		SELECT r.rdb$field_name, r.rdb$field_source, f.rdb$field_type, f.rdb$field_sub_type,
+		SELECT r.rdb$relation_name, r.rdb$field_name, r.rdb$field_source, f.rdb$field_type, f.rdb$field_sub_type,
 			f.rdb$field_length, f.rdb$field_precision, f.rdb$field_scale,
 			COALESCE(r.rdb$default_source, f.rdb$default_source) rdb$default_source,
-			COALESCE(r.rdb$null_flag, f.rdb$null_flag) rdb$null_flag
+			COALESCE(r.rdb$null_flag, f.rdb$null_flag) rdb$null_flag,
+			cs.rdb$character_set_name, co.rdb$collation_name, f.rdb$character_length, f.rdb$segment_length,
+			f.rdb$dimensions, f.rdb$computed_source, %s
 		FROM rdb$relation_fields r
 		JOIN rdb$fields f ON r.rdb$field_source = f.rdb$field_name
-		WHERE r.rdb$relation_name = ?
-		ORDER BY r.rdb$field_position`
+		LEFT JOIN rdb$character_sets cs ON f.rdb$character_set_id = cs.rdb$character_set_id
+		LEFT JOIN rdb$collations co ON COALESCE(r.rdb$collation_id, f.rdb$collation_id) = co.rdb$collation_id
+			AND f.rdb$character_set_id = co.rdb$character_set_id
+		%s
+		ORDER BY r.rdb$relation_name, r.rdb$field_position`
 
-	rows, err := db.Query(query, tableName)
+	// Identity columns were introduced in Firebird 3.
+	identity := "CAST(NULL AS SMALLINT), CAST(NULL AS CHAR(63))"
+	var identities bool
+	if identities, err = hasField(ctx, db, "RDB$RELATION_FIELDS", "RDB$IDENTITY_TYPE"); err != nil {
+		return
+	} else if identities {
+		identity = "r.rdb$identity_type, r.rdb$generator_name"
+	}
+
+	rows, err := db.QueryContext(ctx, fmt.Sprintf(query, identity, where), args...)
 	if err != nil {
 		return
 	}
 	defer rows.Close()
 
+	relations = make(map[string][]*Column)
+	// Array columns keyed by field source, whose dimensions are loaded below.
+	arrays := make(map[string][]*Column)
 	for rows.Next() {
 		var col Column
+		var relationName string
 		var sqlType int16
+		var dimensions, identityType sql.NullInt64
+		var generator sql.NullString
 		if err = rows.Scan(
+			&relationName,
 			&col.Name,
 			&col.Domain,
 			&sqlType,
@@ -50,25 +144,160 @@ func Columns(db *sql.DB, tableName string) (columns []*Column, err error) {
 			&col.Precision,
 			&col.Scale,
 			&col.Default,
-			&col.Nullable); err != nil {
+			&col.Nullable,
+			&col.CharacterSet,
+			&col.Collation,
+			&col.CharacterLength,
+			&col.SegmentSize,
+			&dimensions,
+			&col.Computed,
+			&identityType,
+			&generator); err != nil {
 			return
 		}
+		relationName = strings.TrimRightFunc(relationName, unicode.IsSpace)
 		col.Name = strings.TrimRightFunc(col.Name, unicode.IsSpace)
 		col.Domain = strings.TrimRightFunc(col.Domain, unicode.IsSpace)
+		if dimensions.Int64 > 0 {
+			arrays[col.Domain] = append(arrays[col.Domain], &col)
+		}
 		if strings.HasPrefix(col.Domain, "RDB$") {
 			col.Domain = ""
 		}
 		col.SqlType = sqlTypeFromCode(int(sqlType), int(col.SqlSubtype.Int64))
+		col.TypeCode = int(sqlType)
+		col.InternalSize = int(col.Length)
+		if col.TypeCode == blr_varying {
+			col.InternalSize += 2
+		}
+		if dimensions.Int64 > 0 {
+			col.InternalSize = 8 // array id
+		}
 		if col.Default.Valid {
 			col.Default.String = strings.Replace(col.Default.String, "DEFAULT ", "", 1)
 			col.Default.String = strings.TrimLeftFunc(col.Default.String, unicode.IsSpace)
 		}
-		columns = append(columns, &col)
+		col.CharacterSet.String = strings.TrimRightFunc(col.CharacterSet.String, unicode.IsSpace)
+		col.Collation.String = strings.TrimRightFunc(col.Collation.String, unicode.IsSpace)
+		if col.Computed.Valid {
+			col.Computed.String = strings.TrimSpace(col.Computed.String)
+		}
+		// Firebird 3 only has BY DEFAULT and may leave the type unset.
+		if generator.Valid {
+			col.Generator = strings.TrimRightFunc(generator.String, unicode.IsSpace)
+			col.Identity = "BY DEFAULT"
+			if identityType.Valid && identityType.Int64 == 0 {
+				col.Identity = "ALWAYS"
+			}
+		}
+		relations[relationName] = append(relations[relationName], &col)
+	}
+	if err = rows.Err(); err != nil || len(arrays) == 0 {
+		return
+	}
+	rows.Close()
+
+	const dimensionsQuery = `
+		SELECT d.rdb$field_name, d.rdb$lower_bound, d.rdb$upper_bound
+		FROM rdb$field_dimensions d
+		WHERE d.rdb$field_name IN (SELECT r.rdb$field_source FROM rdb$relation_fields r %s)
+		ORDER BY d.rdb$field_name, d.rdb$dimension`
+
+	if rows, err = db.QueryContext(ctx, fmt.Sprintf(dimensionsQuery, where), args...); err != nil {
+		return
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		var dim ArrayDimension
+		if err = rows.Scan(&name, &dim.Lower, &dim.Upper); err != nil {
+			return
+		}
+		for _, col := range arrays[strings.TrimRightFunc(name, unicode.IsSpace)] {
+			col.Dimensions = append(col.Dimensions, dim)
+		}
 	}
 	err = rows.Err()
 	return
 }
 
+var (
+	scanTypeInt64       = reflect.TypeOf(int64(0))
+	scanTypeNullInt64   = reflect.TypeOf(sql.NullInt64{})
+	scanTypeFloat64     = reflect.TypeOf(float64(0))
+	scanTypeNullFloat64 = reflect.TypeOf(sql.NullFloat64{})
+	scanTypeBool        = reflect.TypeOf(false)
+	scanTypeNullBool    = reflect.TypeOf(sql.NullBool{})
+	scanTypeString      = reflect.TypeOf("")
+	scanTypeNullString  = reflect.TypeOf(sql.NullString{})
+	scanTypeDecimal     = reflect.TypeOf(Decimal(""))
+	scanTypeNullDecimal = reflect.TypeOf(NullDecimal{})
+	scanTypeTime        = reflect.TypeOf(time.Time{})
+	scanTypeNullTime    = reflect.TypeOf(sql.NullTime{})
+	scanTypeBytes       = reflect.TypeOf([]byte(nil))
+	scanTypeAny         = reflect.TypeOf((*interface{})(nil)).Elem()
+)
+
+// ScanType returns the Go type a value of the column can be scanned into.
+// Nullable columns map to the sql.Null types, except for binary values,
+// which scan into a nil []byte. NUMERIC and DECIMAL columns with a scale,
+// and types wider than 64 bits, map to Decimal to keep their exact decimal
+// representation.
+func (c *Column) ScanType() reflect.Type {
+	notNull := c.Nullable.Valid && c.Nullable.Bool
+	pick := func(t, nullT reflect.Type) reflect.Type {
+		if notNull {
+			return t
+		}
+		return nullT
+	}
+	if len(c.Dimensions) > 0 {
+		// Array values are returned in a driver-specific form.
+		return scanTypeAny
+	}
+	switch c.SqlType {
+	case "SMALLINT", "INTEGER", "BIGINT":
+		return pick(scanTypeInt64, scanTypeNullInt64)
+	case "NUMERIC", "DECIMAL":
+		if c.Scale == 0 && c.numericPrecision() <= 18 {
+			return pick(scanTypeInt64, scanTypeNullInt64)
+		}
+		return pick(scanTypeDecimal, scanTypeNullDecimal)
+	case "INT128", "DECFLOAT":
+		return pick(scanTypeDecimal, scanTypeNullDecimal)
+	case "FLOAT", "DOUBLE PRECISION":
+		return pick(scanTypeFloat64, scanTypeNullFloat64)
+	case "BOOLEAN":
+		return pick(scanTypeBool, scanTypeNullBool)
+	case "DATE", "TIME", "TIMESTAMP", "TIME WITH TIME ZONE", "TIMESTAMP WITH TIME ZONE":
+		return pick(scanTypeTime, scanTypeNullTime)
+	case "CHAR", "VARCHAR":
+		if c.CharacterSet.String == "OCTETS" {
+			return scanTypeBytes
+		}
+		return pick(scanTypeString, scanTypeNullString)
+	case "BLOB":
+		if c.SqlSubtype.Int64 == 1 {
+			return pick(scanTypeString, scanTypeNullString)
+		}
+		return scanTypeBytes
+	}
+	return scanTypeAny
+}
+
+// GoType returns the name of the column's ScanType as written in Go source
+// outside this package, for use by code generators.
+func (c *Column) GoType() string {
+	switch t := c.ScanType(); t {
+	case scanTypeBytes:
+		return "[]byte"
+	case scanTypeAny:
+		return "interface{}"
+	default:
+		return t.String()
+	}
+}
+
 func sqlTypeFromCode(code, subType int) string {
 	switch code {
 	case sql_text, blr_text:
@@ -128,6 +357,24 @@ func sqlTypeFromCode(code, subType int) string {
 		case 2:
 			return "DECIMAL"
 		}
+	case sql_boolean, blr_bool:
+		return "BOOLEAN"
+	case sql_dec16, blr_dec64, sql_dec34, blr_dec128:
+		return "DECFLOAT"
+	case sql_int128, blr_int128:
+		switch subType {
+		case 0:
+			return "INT128"
+		case 1:
+			return "NUMERIC"
+		case 2:
+			return "DECIMAL"
+		}
+	// The extended time zone types only differ in their client representation.
+	case sql_time_tz, blr_sql_time_tz, sql_time_tz_ex, blr_ex_time_tz:
+		return "TIME WITH TIME ZONE"
+	case sql_timestamp_tz, blr_timestamp_tz, sql_timestamp_tz_ex, blr_ex_timestamp_tz:
+		return "TIMESTAMP WITH TIME ZONE"
 	}
 	return fmt.Sprintf("UNKNOWN %d, %d", code, subType)
 }
@@ -2,31 +2,35 @@ package fbx
 
 import (
 	"database/sql"
+	"fmt"
 	_ "github.com/rowland/firebirdsql"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 // Length of CHAR and VARCHAR fields assumes UTF8 character set.
+// CharacterLength is independent of it.
 var expectedColumns = []Column{
-	{Name: "ID", Domain: "", SqlType: "BIGINT", SqlSubtype: sql.NullInt64{0, true}, Length: 8, Precision: sql.NullInt64{0, true}, Scale: 0, Default: sql.NullString{"", false}, Nullable: sql.NullBool{true, true}},
-	{Name: "FLAG", Domain: "BOOLEAN", SqlType: "INTEGER", SqlSubtype: sql.NullInt64{0, true}, Length: 4, Precision: sql.NullInt64{0, true}, Scale: 0, Default: sql.NullString{"", false}, Nullable: sql.NullBool{false, false}},
-	{Name: "BINARY", Domain: "", SqlType: "BLOB", SqlSubtype: sql.NullInt64{0, true}, Length: 8, Precision: sql.NullInt64{0, false}, Scale: 0, Default: sql.NullString{"", false}, Nullable: sql.NullBool{false, false}},
-	{Name: "I", Domain: "", SqlType: "INTEGER", SqlSubtype: sql.NullInt64{0, true}, Length: 4, Precision: sql.NullInt64{0, true}, Scale: 0, Default: sql.NullString{"", false}, Nullable: sql.NullBool{false, false}},
-	{Name: "I32", Domain: "", SqlType: "INTEGER", SqlSubtype: sql.NullInt64{0, true}, Length: 4, Precision: sql.NullInt64{0, true}, Scale: 0, Default: sql.NullString{"0", true}, Nullable: sql.NullBool{false, false}},
-	{Name: "I64", Domain: "", SqlType: "BIGINT", SqlSubtype: sql.NullInt64{0, true}, Length: 8, Precision: sql.NullInt64{0, true}, Scale: 0, Default: sql.NullString{"", false}, Nullable: sql.NullBool{false, false}},
-	{Name: "F32", Domain: "", SqlType: "FLOAT", SqlSubtype: sql.NullInt64{0, false}, Length: 4, Precision: sql.NullInt64{0, false}, Scale: 0, Default: sql.NullString{"", false}, Nullable: sql.NullBool{false, false}},
-	{Name: "F64", Domain: "", SqlType: "DOUBLE PRECISION", SqlSubtype: sql.NullInt64{0, false}, Length: 8, Precision: sql.NullInt64{0, false}, Scale: 0, Default: sql.NullString{"0.0", true}, Nullable: sql.NullBool{false, false}},
-	{Name: "C", Domain: "", SqlType: "CHAR", SqlSubtype: sql.NullInt64{0, true}, Length: 4, Precision: sql.NullInt64{0, false}, Scale: 0, Default: sql.NullString{"", false}, Nullable: sql.NullBool{false, false}},
-	{Name: "CS", Domain: "ALPHABET", SqlType: "CHAR", SqlSubtype: sql.NullInt64{0, true}, Length: 104, Precision: sql.NullInt64{0, false}, Scale: 0, Default: sql.NullString{"", false}, Nullable: sql.NullBool{false, false}},
-	{Name: "V", Domain: "", SqlType: "VARCHAR", SqlSubtype: sql.NullInt64{0, true}, Length: 4, Precision: sql.NullInt64{0, false}, Scale: 0, Default: sql.NullString{"", false}, Nullable: sql.NullBool{false, false}},
-	{Name: "VS", Domain: "ALPHA", SqlType: "VARCHAR", SqlSubtype: sql.NullInt64{0, true}, Length: 104, Precision: sql.NullInt64{0, false}, Scale: 0, Default: sql.NullString{"", false}, Nullable: sql.NullBool{false, false}},
-	{Name: "M", Domain: "", SqlType: "BLOB", SqlSubtype: sql.NullInt64{1, true}, Length: 8, Precision: sql.NullInt64{0, false}, Scale: 0, Default: sql.NullString{"", false}, Nullable: sql.NullBool{false, false}},
-	{Name: "DT", Domain: "", SqlType: "DATE", SqlSubtype: sql.NullInt64{0, false}, Length: 4, Precision: sql.NullInt64{0, false}, Scale: 0, Default: sql.NullString{"", false}, Nullable: sql.NullBool{false, false}},
-	{Name: "TM", Domain: "", SqlType: "TIME", SqlSubtype: sql.NullInt64{0, false}, Length: 4, Precision: sql.NullInt64{0, false}, Scale: 0, Default: sql.NullString{"", false}, Nullable: sql.NullBool{false, false}},
-	{Name: "TS", Domain: "", SqlType: "TIMESTAMP", SqlSubtype: sql.NullInt64{0, false}, Length: 8, Precision: sql.NullInt64{0, false}, Scale: 0, Default: sql.NullString{"", false}, Nullable: sql.NullBool{false, false}},
-	{Name: "N92", Domain: "", SqlType: "NUMERIC", SqlSubtype: sql.NullInt64{1, true}, Length: 4, Precision: sql.NullInt64{9, true}, Scale: -2, Default: sql.NullString{"", false}, Nullable: sql.NullBool{false, false}},
-	{Name: "D92", Domain: "", SqlType: "DECIMAL", SqlSubtype: sql.NullInt64{2, true}, Length: 4, Precision: sql.NullInt64{9, true}, Scale: -2, Default: sql.NullString{"", false}, Nullable: sql.NullBool{false, false}},
+	{Name: "ID", Domain: "", SqlType: "BIGINT", SqlSubtype: sql.NullInt64{0, true}, Length: 8, Precision: sql.NullInt64{0, true}, Scale: 0, Default: sql.NullString{"", false}, Nullable: sql.NullBool{true, true}, TypeCode: blr_int64, InternalSize: 8},
+	{Name: "FLAG", Domain: "BOOLEAN", SqlType: "INTEGER", SqlSubtype: sql.NullInt64{0, true}, Length: 4, Precision: sql.NullInt64{0, true}, Scale: 0, Default: sql.NullString{"", false}, Nullable: sql.NullBool{false, false}, TypeCode: blr_long, InternalSize: 4},
+	{Name: "BINARY", Domain: "", SqlType: "BLOB", SqlSubtype: sql.NullInt64{0, true}, Length: 8, Precision: sql.NullInt64{0, false}, Scale: 0, Default: sql.NullString{"", false}, Nullable: sql.NullBool{false, false}, TypeCode: blr_blob, InternalSize: 8, SegmentSize: sql.NullInt64{80, true}},
+	{Name: "I", Domain: "", SqlType: "INTEGER", SqlSubtype: sql.NullInt64{0, true}, Length: 4, Precision: sql.NullInt64{0, true}, Scale: 0, Default: sql.NullString{"", false}, Nullable: sql.NullBool{false, false}, TypeCode: blr_long, InternalSize: 4},
+	{Name: "I32", Domain: "", SqlType: "INTEGER", SqlSubtype: sql.NullInt64{0, true}, Length: 4, Precision: sql.NullInt64{0, true}, Scale: 0, Default: sql.NullString{"0", true}, Nullable: sql.NullBool{false, false}, TypeCode: blr_long, InternalSize: 4},
+	{Name: "I64", Domain: "", SqlType: "BIGINT", SqlSubtype: sql.NullInt64{0, true}, Length: 8, Precision: sql.NullInt64{0, true}, Scale: 0, Default: sql.NullString{"", false}, Nullable: sql.NullBool{false, false}, TypeCode: blr_int64, InternalSize: 8},
+	{Name: "F32", Domain: "", SqlType: "FLOAT", SqlSubtype: sql.NullInt64{0, false}, Length: 4, Precision: sql.NullInt64{0, false}, Scale: 0, Default: sql.NullString{"", false}, Nullable: sql.NullBool{false, false}, TypeCode: blr_float, InternalSize: 4},
+	{Name: "F64", Domain: "", SqlType: "DOUBLE PRECISION", SqlSubtype: sql.NullInt64{0, false}, Length: 8, Precision: sql.NullInt64{0, false}, Scale: 0, Default: sql.NullString{"0.0", true}, Nullable: sql.NullBool{false, false}, TypeCode: blr_double, InternalSize: 8},
+	{Name: "C", Domain: "", SqlType: "CHAR", SqlSubtype: sql.NullInt64{0, true}, Length: 4, Precision: sql.NullInt64{0, false}, Scale: 0, Default: sql.NullString{"", false}, Nullable: sql.NullBool{false, false}, TypeCode: blr_text, InternalSize: 4, CharacterSet: sql.NullString{"UTF8", true}, Collation: sql.NullString{"UTF8", true}, CharacterLength: sql.NullInt64{1, true}},
+	{Name: "CS", Domain: "ALPHABET", SqlType: "CHAR", SqlSubtype: sql.NullInt64{0, true}, Length: 104, Precision: sql.NullInt64{0, false}, Scale: 0, Default: sql.NullString{"", false}, Nullable: sql.NullBool{false, false}, TypeCode: blr_text, InternalSize: 104, CharacterSet: sql.NullString{"UTF8", true}, Collation: sql.NullString{"UTF8", true}, CharacterLength: sql.NullInt64{26, true}},
+	{Name: "V", Domain: "", SqlType: "VARCHAR", SqlSubtype: sql.NullInt64{0, true}, Length: 4, Precision: sql.NullInt64{0, false}, Scale: 0, Default: sql.NullString{"", false}, Nullable: sql.NullBool{false, false}, TypeCode: blr_varying, InternalSize: 6, CharacterSet: sql.NullString{"UTF8", true}, Collation: sql.NullString{"UTF8", true}, CharacterLength: sql.NullInt64{1, true}},
+	{Name: "VS", Domain: "ALPHA", SqlType: "VARCHAR", SqlSubtype: sql.NullInt64{0, true}, Length: 104, Precision: sql.NullInt64{0, false}, Scale: 0, Default: sql.NullString{"", false}, Nullable: sql.NullBool{false, false}, TypeCode: blr_varying, InternalSize: 106, CharacterSet: sql.NullString{"UTF8", true}, Collation: sql.NullString{"UTF8", true}, CharacterLength: sql.NullInt64{26, true}},
+	{Name: "M", Domain: "", SqlType: "BLOB", SqlSubtype: sql.NullInt64{1, true}, Length: 8, Precision: sql.NullInt64{0, false}, Scale: 0, Default: sql.NullString{"", false}, Nullable: sql.NullBool{false, false}, TypeCode: blr_blob, InternalSize: 8, CharacterSet: sql.NullString{"UTF8", true}, Collation: sql.NullString{"UTF8", true}, SegmentSize: sql.NullInt64{80, true}},
+	{Name: "DT", Domain: "", SqlType: "DATE", SqlSubtype: sql.NullInt64{0, false}, Length: 4, Precision: sql.NullInt64{0, false}, Scale: 0, Default: sql.NullString{"", false}, Nullable: sql.NullBool{false, false}, TypeCode: blr_sql_date, InternalSize: 4},
+	{Name: "TM", Domain: "", SqlType: "TIME", SqlSubtype: sql.NullInt64{0, false}, Length: 4, Precision: sql.NullInt64{0, false}, Scale: 0, Default: sql.NullString{"", false}, Nullable: sql.NullBool{false, false}, TypeCode: blr_sql_time, InternalSize: 4},
+	{Name: "TS", Domain: "", SqlType: "TIMESTAMP", SqlSubtype: sql.NullInt64{0, false}, Length: 8, Precision: sql.NullInt64{0, false}, Scale: 0, Default: sql.NullString{"", false}, Nullable: sql.NullBool{false, false}, TypeCode: blr_timestamp, InternalSize: 8},
+	{Name: "N92", Domain: "", SqlType: "NUMERIC", SqlSubtype: sql.NullInt64{1, true}, Length: 4, Precision: sql.NullInt64{9, true}, Scale: -2, Default: sql.NullString{"", false}, Nullable: sql.NullBool{false, false}, TypeCode: blr_long, InternalSize: 4},
+	{Name: "D92", Domain: "", SqlType: "DECIMAL", SqlSubtype: sql.NullInt64{2, true}, Length: 4, Precision: sql.NullInt64{9, true}, Scale: -2, Default: sql.NullString{"", false}, Nullable: sql.NullBool{false, false}, TypeCode: blr_long, InternalSize: 4},
 }
 
 const sqlSampleSchema = `
@@ -83,3 +87,397 @@ func TestColumns(t *testing.T) {
 		}
 	}
 }
+
+func TestAllColumns(t *testing.T) {
+	db, err := sql.Open("firebirdsql_createdb", "sysdba:masterkey@localhost:3050/tmp/fbx_test_all_columns.fdb")
+	if err != nil {
+		t.Fatalf("Error creating database: %s", err)
+	}
+	defer db.Close()
+
+	err = ExecScript(db, sqlSampleSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	relations, err := AllColumns(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(relations) != 2 {
+		t.Fatalf("Expected <2> relations, got <%d>.", len(relations))
+	}
+	cols := relations["TEST"]
+	if len(cols) != len(expectedColumns) {
+		t.Fatalf("Expected <%d>, got <%d>.", len(expectedColumns), len(cols))
+	}
+	for i, exp := range expectedColumns {
+		if !reflect.DeepEqual(&exp, cols[i]) {
+			t.Errorf("Expected %#v,\n got %#v", &exp, cols[i])
+		}
+	}
+	if names := relations["TEST2"]; len(names) != 3 || names[0].Name != "A" || names[2].Name != "DATA" {
+		t.Errorf("Unexpected TEST2 columns %v", names)
+	}
+
+	relations, err = ColumnsOfRelations(db, "TEST2", "MISSING")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(relations) != 1 || len(relations["TEST2"]) != 3 {
+		t.Errorf("Expected only the <3> columns of TEST2, got %v", relations)
+	}
+
+	// More names than fit in one IN list are queried in batches.
+	names := make([]string, 2*maxInList)
+	for i := range names {
+		names[i] = fmt.Sprintf("MISSING%d", i)
+	}
+	names = append(names, "TEST")
+	relations, err = ColumnsOfRelations(db, names...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(relations) != 1 || len(relations["TEST"]) != len(expectedColumns) {
+		t.Errorf("Expected only the <%d> columns of TEST, got %d relations", len(expectedColumns), len(relations))
+	}
+}
+
+func TestSqlTypeFromCode(t *testing.T) {
+	tests := []struct {
+		code, subType int
+		exp           string
+	}{
+		{blr_short, 0, "SMALLINT"},
+		{blr_long, 1, "NUMERIC"},
+		{blr_int64, 2, "DECIMAL"},
+		{blr_varying, 0, "VARCHAR"},
+		{blr_timestamp, 0, "TIMESTAMP"},
+		{blr_bool, 0, "BOOLEAN"},
+		{sql_boolean, 0, "BOOLEAN"},
+		{blr_dec64, 0, "DECFLOAT"},
+		{blr_dec128, 0, "DECFLOAT"},
+		{sql_dec16, 0, "DECFLOAT"},
+		{sql_dec34, 0, "DECFLOAT"},
+		{blr_int128, 0, "INT128"},
+		{blr_int128, 1, "NUMERIC"},
+		{blr_int128, 2, "DECIMAL"},
+		{sql_int128, 0, "INT128"},
+		{blr_sql_time_tz, 0, "TIME WITH TIME ZONE"},
+		{blr_ex_time_tz, 0, "TIME WITH TIME ZONE"},
+		{sql_time_tz, 0, "TIME WITH TIME ZONE"},
+		{sql_time_tz_ex, 0, "TIME WITH TIME ZONE"},
+		{blr_timestamp_tz, 0, "TIMESTAMP WITH TIME ZONE"},
+		{blr_ex_timestamp_tz, 0, "TIMESTAMP WITH TIME ZONE"},
+		{sql_timestamp_tz, 0, "TIMESTAMP WITH TIME ZONE"},
+		{sql_timestamp_tz_ex, 0, "TIMESTAMP WITH TIME ZONE"},
+		{99, 0, "UNKNOWN 99, 0"},
+	}
+	for _, tt := range tests {
+		if got := sqlTypeFromCode(tt.code, tt.subType); got != tt.exp {
+			t.Errorf("%d, %d: expected <%s>, got <%s>", tt.code, tt.subType, tt.exp, got)
+		}
+	}
+}
+
+func TestScanType(t *testing.T) {
+	exp := []string{
+		"int64", "sql.NullInt64", "[]byte", "sql.NullInt64", "sql.NullInt64", "sql.NullInt64",
+		"sql.NullFloat64", "sql.NullFloat64", "sql.NullString", "sql.NullString", "sql.NullString", "sql.NullString",
+		"sql.NullString", "sql.NullTime", "sql.NullTime", "sql.NullTime", "fbx.NullDecimal", "fbx.NullDecimal",
+	}
+	for i, col := range expectedColumns {
+		if got := col.GoType(); got != exp[i] {
+			t.Errorf("%s: expected <%s>, got <%s>", col.Name, exp[i], got)
+		}
+	}
+
+	notNull := sql.NullBool{true, true}
+	tests := []struct {
+		col Column
+		exp reflect.Type
+	}{
+		{Column{SqlType: "NUMERIC", Precision: sql.NullInt64{18, true}, Nullable: notNull}, reflect.TypeOf(int64(0))},
+		{Column{SqlType: "NUMERIC", Precision: sql.NullInt64{18, true}, Scale: -2, Nullable: notNull}, reflect.TypeOf(Decimal(""))},
+		{Column{SqlType: "DECIMAL", Precision: sql.NullInt64{38, true}}, reflect.TypeOf(NullDecimal{})},
+		{Column{SqlType: "INT128", Nullable: notNull}, reflect.TypeOf(Decimal(""))},
+		{Column{SqlType: "BOOLEAN"}, reflect.TypeOf(sql.NullBool{})},
+		{Column{SqlType: "TIMESTAMP WITH TIME ZONE", Nullable: notNull}, reflect.TypeOf(time.Time{})},
+		{Column{SqlType: "VARCHAR", CharacterSet: sql.NullString{"OCTETS", true}}, reflect.TypeOf([]byte(nil))},
+		{Column{SqlType: "BLOB", SqlSubtype: sql.NullInt64{1, true}, Nullable: notNull}, reflect.TypeOf("")},
+		{Column{SqlType: "BLOB", SqlSubtype: sql.NullInt64{2, true}, Nullable: notNull}, reflect.TypeOf([]byte(nil))},
+		{Column{SqlType: "UNKNOWN 99, 0"}, reflect.TypeOf((*interface{})(nil)).Elem()},
+		{Column{SqlType: "INTEGER", Dimensions: []ArrayDimension{{1, 3}}}, reflect.TypeOf((*interface{})(nil)).Elem()},
+	}
+	for _, tt := range tests {
+		if got := tt.col.ScanType(); got != tt.exp {
+			t.Errorf("%s: expected <%s>, got <%s>", tt.col.SqlType, tt.exp, got)
+		}
+	}
+}
+
+func TestScanTypeRows(t *testing.T) {
+	db, err := sql.Open("firebirdsql_createdb", "sysdba:masterkey@localhost:3050/tmp/fbx_test_scan_type_rows.fdb")
+	if err != nil {
+		t.Fatalf("Error creating database: %s", err)
+	}
+	defer db.Close()
+
+	err = ExecScript(db, sqlSampleSchema+`
+		INSERT INTO TEST (ID, FLAG, BINARY, I, I32, I64, F32, F64, C, CS, V, VS, M, DT, TM, TS, N92, D92)
+		VALUES (1, 1, x'00FF', -1, 32, 64, 1.5, 2.25, 'c', 'alphabet', 'v', 'alpha', 'memo',
+			'2024-02-29', '13:14:15', '2024-02-29 13:14:15', 1234567.89, -0.01);
+		INSERT INTO TEST (ID) VALUES (2);`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cols, err := Columns(db, "TEST")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows, err := db.Query("SELECT * FROM TEST ORDER BY ID")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	var scanned [][]interface{}
+	for rows.Next() {
+		dest := make([]interface{}, len(cols))
+		for i, col := range cols {
+			dest[i] = reflect.New(col.ScanType()).Interface()
+		}
+		if err = rows.Scan(dest...); err != nil {
+			t.Fatal(err)
+		}
+		scanned = append(scanned, dest)
+	}
+	if err = rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(scanned) != 2 {
+		t.Fatalf("Expected <2> rows, got <%d>", len(scanned))
+	}
+
+	for i, col := range cols {
+		v := reflect.ValueOf(scanned[0][i]).Elem()
+		if got := strings.Replace(v.Type().String(), "uint8", "byte", 1); got != col.GoType() {
+			t.Errorf("%s: GoType <%s> does not match scanned type <%s>", col.Name, col.GoType(), got)
+		}
+	}
+	exp := map[string]interface{}{
+		"ID":  int64(1),
+		"I":   sql.NullInt64{-1, true},
+		"F64": sql.NullFloat64{2.25, true},
+		"C":   sql.NullString{"c", true},
+		"M":   sql.NullString{"memo", true},
+		"N92": NullDecimal{"1234567.89", true},
+		"D92": NullDecimal{"-0.01", true},
+	}
+	for i, col := range cols {
+		got := reflect.ValueOf(scanned[0][i]).Elem().Interface()
+		if e, ok := exp[col.Name]; ok && !reflect.DeepEqual(e, got) {
+			t.Errorf("%s: expected %#v, got %#v", col.Name, e, got)
+		}
+		switch v := got.(type) {
+		case sql.NullTime:
+			if !v.Valid || v.Time.Hour() != 13 && col.SqlType != "DATE" {
+				t.Errorf("%s: unexpected time %v", col.Name, v)
+			}
+		case []byte:
+			if col.Name == "BINARY" && !reflect.DeepEqual(v, []byte{0x00, 0xFF}) {
+				t.Errorf("%s: expected <00FF>, got <%X>", col.Name, v)
+			}
+		}
+	}
+	// Every nullable column of the second row is NULL.
+	for i, col := range cols[1:] {
+		v := reflect.ValueOf(scanned[1][i+1]).Elem()
+		if valid := v.FieldByName("Valid"); valid.IsValid() && valid.Bool() {
+			t.Errorf("%s: expected NULL, got %#v", col.Name, v.Interface())
+		} else if v.Kind() == reflect.Slice && !v.IsNil() {
+			t.Errorf("%s: expected nil, got %#v", col.Name, v.Interface())
+		}
+	}
+}
+
+func TestColumnsModernTypes(t *testing.T) {
+	const sqlSchema = `
+		CREATE TABLE TEST (
+			B BOOLEAN,
+			I128 INT128,
+			N38 NUMERIC(38,4),
+			DF16 DECFLOAT(16),
+			DF34 DECFLOAT(34),
+			TMTZ TIME WITH TIME ZONE,
+			TSTZ TIMESTAMP WITH TIME ZONE);`
+
+	db, err := sql.Open("firebirdsql_createdb", "sysdba:masterkey@localhost:3050/tmp/fbx_test_columns_modern_types.fdb")
+	if err != nil {
+		t.Fatalf("Error creating database: %s", err)
+	}
+	defer db.Close()
+
+	if err = ExecScript(db, sqlSchema); err != nil {
+		t.Skipf("Server does not support Firebird 4 types: %s", err)
+	}
+
+	cols, err := Columns(db, "TEST")
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := []string{"BOOLEAN", "INT128", "NUMERIC", "DECFLOAT", "DECFLOAT", "TIME WITH TIME ZONE", "TIMESTAMP WITH TIME ZONE"}
+	if len(cols) != len(exp) {
+		t.Fatalf("Expected <%d>, got <%d>.", len(exp), len(cols))
+	}
+	for i, col := range cols {
+		if col.SqlType != exp[i] {
+			t.Errorf("%s: expected <%s>, got <%s>", col.Name, exp[i], col.SqlType)
+		}
+	}
+	if cols[3].Precision.Int64 != 16 || cols[4].Precision.Int64 != 34 {
+		t.Errorf("Expected DECFLOAT precisions <16> and <34>, got <%d> and <%d>", cols[3].Precision.Int64, cols[4].Precision.Int64)
+	}
+}
+
+func TestColumnsCharacterSets(t *testing.T) {
+	const sqlSchema = `
+		CREATE TABLE TEST (
+			W VARCHAR(10) CHARACTER SET WIN1252 COLLATE PXW_INTL,
+			U VARCHAR(10) CHARACTER SET UTF8 COLLATE UNICODE_CI,
+			O CHAR(3) CHARACTER SET OCTETS,
+			T BLOB SUB_TYPE TEXT SEGMENT SIZE 200 CHARACTER SET WIN1252);`
+
+	db, err := sql.Open("firebirdsql_createdb", "sysdba:masterkey@localhost:3050/tmp/fbx_test_columns_character_sets.fdb")
+	if err != nil {
+		t.Fatalf("Error creating database: %s", err)
+	}
+	defer db.Close()
+
+	err = ExecScript(db, sqlSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cols, err := Columns(db, "TEST")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cols) != 4 {
+		t.Fatalf("Expected <4>, got <%d>.", len(cols))
+	}
+	tests := []struct {
+		charset, collation string
+		length             int16
+		charLength         int64
+	}{
+		{"WIN1252", "PXW_INTL", 10, 10},
+		{"UTF8", "UNICODE_CI", 40, 10},
+		{"OCTETS", "OCTETS", 3, 3},
+	}
+	for i, tt := range tests {
+		col := cols[i]
+		if col.CharacterSet.String != tt.charset || col.Collation.String != tt.collation {
+			t.Errorf("%s: expected %s / %s, got %s / %s", col.Name, tt.charset, tt.collation, col.CharacterSet.String, col.Collation.String)
+		}
+		if col.Length != tt.length || col.CharacterLength.Int64 != tt.charLength {
+			t.Errorf("%s: expected lengths <%d> and <%d>, got <%d> and <%d>", col.Name, tt.length, tt.charLength, col.Length, col.CharacterLength.Int64)
+		}
+	}
+	if blob := cols[3]; blob.CharacterSet.String != "WIN1252" || blob.SegmentSize.Int64 != 200 {
+		t.Errorf("Expected WIN1252 text blob with segment size <200>, got %s and <%d>", blob.CharacterSet.String, blob.SegmentSize.Int64)
+	}
+}
+
+func TestColumnsComputedAndArrays(t *testing.T) {
+	const sqlSchema = `
+		CREATE TABLE TEST (
+			ID INTEGER NOT NULL,
+			QTY INTEGER,
+			DOUBLE_QTY COMPUTED BY (QTY * 2),
+			GRID INTEGER[3, 0:4],
+			TAGS VARCHAR(10)[5] CHARACTER SET UTF8);`
+
+	db, err := sql.Open("firebirdsql_createdb", "sysdba:masterkey@localhost:3050/tmp/fbx_test_columns_computed_and_arrays.fdb")
+	if err != nil {
+		t.Fatalf("Error creating database: %s", err)
+	}
+	defer db.Close()
+
+	err = ExecScript(db, sqlSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cols, err := Columns(db, "TEST")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cols) != 5 {
+		t.Fatalf("Expected <5>, got <%d>.", len(cols))
+	}
+	if computed := cols[2]; computed.Computed != (sql.NullString{"(QTY * 2)", true}) || computed.Insertable() {
+		t.Errorf("Expected computed column (QTY * 2), got %#v", computed.Computed)
+	}
+	if !cols[1].Insertable() || cols[1].Computed.Valid {
+		t.Errorf("Expected %s to be insertable", cols[1].Name)
+	}
+	if exp := []ArrayDimension{{1, 3}, {0, 4}}; !reflect.DeepEqual(cols[3].Dimensions, exp) {
+		t.Errorf("Expected dimensions %v, got %v", exp, cols[3].Dimensions)
+	}
+	if exp, got := "VARCHAR(10)[5] CHARACTER SET UTF8", cols[4].Declaration(); got != exp {
+		t.Errorf("Expected <%s>, got <%s>", exp, got)
+	}
+	if cols[0].Dimensions != nil || cols[1].Dimensions != nil {
+		t.Errorf("Expected no dimensions on scalar columns")
+	}
+}
+
+func TestColumnsIdentity(t *testing.T) {
+	const sqlSchema = `
+		CREATE TABLE TEST (
+			ID INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+			NAME VARCHAR(20));`
+
+	db, err := sql.Open("firebirdsql_createdb", "sysdba:masterkey@localhost:3050/tmp/fbx_test_columns_identity.fdb")
+	if err != nil {
+		t.Fatalf("Error creating database: %s", err)
+	}
+	defer db.Close()
+
+	if err = ExecScript(db, sqlSchema); err != nil {
+		t.Skipf("Server does not support identity columns: %s", err)
+	}
+
+	cols, err := Columns(db, "TEST")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cols) != 2 {
+		t.Fatalf("Expected <2>, got <%d>.", len(cols))
+	}
+	if id := cols[0]; id.Identity != "BY DEFAULT" || id.Generator == "" || !id.Insertable() {
+		t.Errorf("Expected BY DEFAULT identity with a generator, got <%s> and <%s>", id.Identity, id.Generator)
+	}
+	if name := cols[1]; name.Identity != "" || name.Generator != "" {
+		t.Errorf("Expected no identity on %s, got <%s>", name.Name, name.Identity)
+	}
+}
+
+func TestInsertable(t *testing.T) {
+	tests := []struct {
+		col Column
+		exp bool
+	}{
+		{Column{SqlType: "INTEGER"}, true},
+		{Column{SqlType: "INTEGER", Computed: sql.NullString{"(A + B)", true}}, false},
+		{Column{SqlType: "INTEGER", Identity: "BY DEFAULT", Generator: "RDB$1"}, true},
+		{Column{SqlType: "INTEGER", Identity: "ALWAYS", Generator: "RDB$1"}, false},
+	}
+	for i, tt := range tests {
+		if got := tt.col.Insertable(); got != tt.exp {
+			t.Errorf("%d: expected <%t>, got <%t>", i, tt.exp, got)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package fbx
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"unicode"
+)
+
+// Constraint describes an entry of RDB$RELATION_CONSTRAINTS. Type is one of
+// PRIMARY KEY, UNIQUE, FOREIGN KEY, CHECK or NOT NULL. IndexName is empty for
+// CHECK and NOT NULL constraints, and Source is only valid for CHECK
+// constraints.
+type Constraint struct {
+	Name      string
+	TableName string
+	Type      string
+	Columns   []string
+	IndexName string
+	Source    sql.NullString
+}
+
+type ForeignKey struct {
+	Name              string
+	TableName         string
+	Columns           []string
+	ReferencedTable   string
+	ReferencedColumns []string
+	OnUpdate          string
+	OnDelete          string
+	IndexName         string
+}
+
+const foreignKeysQuery = `
+	SELECT RC.RDB$CONSTRAINT_NAME, RC.RDB$RELATION_NAME, RC.RDB$INDEX_NAME,
+		REF.RDB$UPDATE_RULE, REF.RDB$DELETE_RULE, UQ.RDB$RELATION_NAME,
+		S.RDB$FIELD_NAME, US.RDB$FIELD_NAME
+	FROM RDB$RELATION_CONSTRAINTS RC
+		JOIN RDB$REF_CONSTRAINTS REF ON RC.RDB$CONSTRAINT_NAME = REF.RDB$CONSTRAINT_NAME
+		JOIN RDB$RELATION_CONSTRAINTS UQ ON REF.RDB$CONST_NAME_UQ = UQ.RDB$CONSTRAINT_NAME
+		JOIN RDB$INDEX_SEGMENTS S ON RC.RDB$INDEX_NAME = S.RDB$INDEX_NAME
+		JOIN RDB$INDEX_SEGMENTS US ON UQ.RDB$INDEX_NAME = US.RDB$INDEX_NAME
+			AND S.RDB$FIELD_POSITION = US.RDB$FIELD_POSITION
+	WHERE RC.RDB$CONSTRAINT_TYPE = 'FOREIGN KEY'`
+
+// ForeignKeys returns the foreign keys declared on tableName.
+func ForeignKeys(db Querier, tableName string) (keys []*ForeignKey, err error) {
+	return ForeignKeysContext(context.Background(), db, tableName)
+}
+
+func ForeignKeysContext(ctx context.Context, db Querier, tableName string) (keys []*ForeignKey, err error) {
+	const query = foreignKeysQuery + `
+		AND RC.RDB$RELATION_NAME = ?
+		ORDER BY RC.RDB$CONSTRAINT_NAME, S.RDB$FIELD_POSITION`
+	return queryForeignKeys(ctx, db, query, tableName)
+}
+
+// ReferencingForeignKeys returns the foreign keys of other tables (or of
+// tableName itself) that reference tableName.
+func ReferencingForeignKeys(db Querier, tableName string) (keys []*ForeignKey, err error) {
+	return ReferencingForeignKeysContext(context.Background(), db, tableName)
+}
+
+func ReferencingForeignKeysContext(ctx context.Context, db Querier, tableName string) (keys []*ForeignKey, err error) {
+	const query = foreignKeysQuery + `
+		AND UQ.RDB$RELATION_NAME = ?
+		ORDER BY RC.RDB$RELATION_NAME, RC.RDB$CONSTRAINT_NAME, S.RDB$FIELD_POSITION`
+	return queryForeignKeys(ctx, db, query, tableName)
+}
+
+func queryForeignKeys(ctx context.Context, db Querier, query string, args ...interface{}) (keys []*ForeignKey, err error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	var key *ForeignKey
+	for rows.Next() {
+		var fk ForeignKey
+		var column, referencedColumn string
+		if err = rows.Scan(
+			&fk.Name,
+			&fk.TableName,
+			&fk.IndexName,
+			&fk.OnUpdate,
+			&fk.OnDelete,
+			&fk.ReferencedTable,
+			&column,
+			&referencedColumn); err != nil {
+			return
+		}
+		fk.Name = strings.TrimRightFunc(fk.Name, unicode.IsSpace)
+		if key == nil || key.Name != fk.Name {
+			key = &fk
+			key.TableName = strings.TrimRightFunc(key.TableName, unicode.IsSpace)
+			key.IndexName = strings.TrimRightFunc(key.IndexName, unicode.IsSpace)
+			key.OnUpdate = strings.TrimRightFunc(key.OnUpdate, unicode.IsSpace)
+			key.OnDelete = strings.TrimRightFunc(key.OnDelete, unicode.IsSpace)
+			key.ReferencedTable = strings.TrimRightFunc(key.ReferencedTable, unicode.IsSpace)
+			keys = append(keys, key)
+		}
+		key.Columns = append(key.Columns, strings.TrimRightFunc(column, unicode.IsSpace))
+		key.ReferencedColumns = append(key.ReferencedColumns, strings.TrimRightFunc(referencedColumn, unicode.IsSpace))
+	}
+	err = rows.Err()
+	return
+}
+
+func Constraints(db Querier, tableName string) (constraints []*Constraint, err error) {
+	return ConstraintsContext(context.Background(), db, tableName)
+}
+
+func ConstraintsContext(ctx context.Context, db Querier, tableName string) (constraints []*Constraint, err error) {
+	const query = `
+		SELECT RC.RDB$CONSTRAINT_NAME, RC.RDB$RELATION_NAME, RC.RDB$CONSTRAINT_TYPE, RC.RDB$INDEX_NAME,
+			(SELECT FIRST 1 T.RDB$TRIGGER_SOURCE
+			FROM RDB$CHECK_CONSTRAINTS CC
+				JOIN RDB$TRIGGERS T ON CC.RDB$TRIGGER_NAME = T.RDB$TRIGGER_NAME
+			WHERE CC.RDB$CONSTRAINT_NAME = RC.RDB$CONSTRAINT_NAME AND RC.RDB$CONSTRAINT_TYPE = 'CHECK')
+		FROM RDB$RELATION_CONSTRAINTS RC
+		WHERE RC.RDB$RELATION_NAME = ?
+		ORDER BY RC.RDB$CONSTRAINT_NAME`
+
+	rows, err := db.QueryContext(ctx, query, tableName)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	byName := make(map[string]*Constraint)
+	for rows.Next() {
+		var c Constraint
+		var indexName sql.NullString
+		if err = rows.Scan(
+			&c.Name,
+			&c.TableName,
+			&c.Type,
+			&indexName,
+			&c.Source); err != nil {
+			return
+		}
+		c.Name = strings.TrimRightFunc(c.Name, unicode.IsSpace)
+		c.TableName = strings.TrimRightFunc(c.TableName, unicode.IsSpace)
+		c.Type = strings.TrimRightFunc(c.Type, unicode.IsSpace)
+		c.IndexName = strings.TrimRightFunc(indexName.String, unicode.IsSpace)
+		if c.Source.Valid {
+			c.Source.String = strings.TrimSpace(c.Source.String)
+		}
+		byName[c.Name] = &c
+		constraints = append(constraints, &c)
+	}
+	if err = rows.Err(); err != nil {
+		return
+	}
+	rows.Close()
+
+	// Index-backed constraints take their columns from the index segments,
+	// NOT NULL constraints name their column in RDB$CHECK_CONSTRAINTS, and
+	// CHECK constraints depend on columns through their triggers.
+	const columnsQuery = `
+		SELECT RC.RDB$CONSTRAINT_NAME, S.RDB$FIELD_NAME, S.RDB$FIELD_POSITION
+		FROM RDB$RELATION_CONSTRAINTS RC
+			JOIN RDB$INDEX_SEGMENTS S ON RC.RDB$INDEX_NAME = S.RDB$INDEX_NAME
+		WHERE RC.RDB$RELATION_NAME = ?
+		UNION
+		SELECT RC.RDB$CONSTRAINT_NAME, CC.RDB$TRIGGER_NAME, 0
+		FROM RDB$RELATION_CONSTRAINTS RC
+			JOIN RDB$CHECK_CONSTRAINTS CC ON RC.RDB$CONSTRAINT_NAME = CC.RDB$CONSTRAINT_NAME
+		WHERE RC.RDB$RELATION_NAME = ? AND RC.RDB$CONSTRAINT_TYPE = 'NOT NULL'
+		UNION
+		SELECT RC.RDB$CONSTRAINT_NAME, D.RDB$FIELD_NAME, 0
+		FROM RDB$RELATION_CONSTRAINTS RC
+			JOIN RDB$CHECK_CONSTRAINTS CC ON RC.RDB$CONSTRAINT_NAME = CC.RDB$CONSTRAINT_NAME
+			JOIN RDB$DEPENDENCIES D ON CC.RDB$TRIGGER_NAME = D.RDB$DEPENDENT_NAME
+				AND D.RDB$DEPENDED_ON_NAME = RC.RDB$RELATION_NAME AND D.RDB$FIELD_NAME IS NOT NULL
+		WHERE RC.RDB$RELATION_NAME = ? AND RC.RDB$CONSTRAINT_TYPE = 'CHECK'
+		ORDER BY 1, 3, 2`
+
+	if rows, err = db.QueryContext(ctx, columnsQuery, tableName, tableName, tableName); err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name, column string
+		var position int
+		if err = rows.Scan(&name, &column, &position); err != nil {
+			return
+		}
+		if c, ok := byName[strings.TrimRightFunc(name, unicode.IsSpace)]; ok {
+			c.Columns = append(c.Columns, strings.TrimRightFunc(column, unicode.IsSpace))
+		}
+	}
+	err = rows.Err()
+	return
+}
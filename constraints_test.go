@@ -0,0 +1,138 @@
+package fbx
+
+import (
+	"database/sql"
+	_ "github.com/rowland/firebirdsql"
+	"reflect"
+	"testing"
+)
+
+const sqlForeignKeySchema = `
+	CREATE TABLE PARENT (A INT NOT NULL, B INT NOT NULL, CONSTRAINT PK_PARENT PRIMARY KEY (A, B));
+	CREATE TABLE CHILD (ID INT NOT NULL PRIMARY KEY, PA INT, PB INT);
+	CREATE TABLE OTHER (ID INT NOT NULL PRIMARY KEY, CHILD_ID INT);
+	ALTER TABLE CHILD ADD CONSTRAINT FK_CHILD_PARENT FOREIGN KEY (PB, PA) REFERENCES PARENT (B, A)
+		ON UPDATE CASCADE ON DELETE SET NULL;
+	ALTER TABLE OTHER ADD CONSTRAINT FK_OTHER_CHILD FOREIGN KEY (CHILD_ID) REFERENCES CHILD;`
+
+func TestForeignKeys(t *testing.T) {
+	db, err := sql.Open("firebirdsql_createdb", "sysdba:masterkey@localhost:3050/tmp/fbx_test_foreign_keys.fdb")
+	if err != nil {
+		t.Fatalf("Error creating database: %s", err)
+	}
+	defer db.Close()
+
+	err = ExecScript(db, sqlForeignKeySchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keys, err := ForeignKeys(db, "CHILD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 1 {
+		t.Fatalf("Expected %d foreign keys, got %d", 1, len(keys))
+	}
+	exp := &ForeignKey{
+		Name:              "FK_CHILD_PARENT",
+		TableName:         "CHILD",
+		Columns:           []string{"PB", "PA"},
+		ReferencedTable:   "PARENT",
+		ReferencedColumns: []string{"B", "A"},
+		OnUpdate:          "CASCADE",
+		OnDelete:          "SET NULL",
+		IndexName:         "FK_CHILD_PARENT",
+	}
+	if !reflect.DeepEqual(exp, keys[0]) {
+		t.Errorf("Expected %#v,\n got %#v", exp, keys[0])
+	}
+}
+
+func TestReferencingForeignKeys(t *testing.T) {
+	db, err := sql.Open("firebirdsql_createdb", "sysdba:masterkey@localhost:3050/tmp/fbx_test_referencing_foreign_keys.fdb")
+	if err != nil {
+		t.Fatalf("Error creating database: %s", err)
+	}
+	defer db.Close()
+
+	err = ExecScript(db, sqlForeignKeySchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keys, err := ReferencingForeignKeys(db, "CHILD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 1 {
+		t.Fatalf("Expected %d foreign keys, got %d", 1, len(keys))
+	}
+	if keys[0].Name != "FK_OTHER_CHILD" || keys[0].TableName != "OTHER" {
+		t.Errorf("Expected FK_OTHER_CHILD on OTHER, got %s on %s", keys[0].Name, keys[0].TableName)
+	}
+	if !reflect.DeepEqual([]string{"ID"}, keys[0].ReferencedColumns) {
+		t.Errorf("Expected referenced columns [ID], got %v", keys[0].ReferencedColumns)
+	}
+	if keys[0].OnDelete != "RESTRICT" {
+		t.Errorf("Expected OnDelete <%s>, got <%s>", "RESTRICT", keys[0].OnDelete)
+	}
+}
+
+func TestConstraints(t *testing.T) {
+	const sqlSchema = `
+		CREATE TABLE TEST (
+			ID INT NOT NULL,
+			CODE VARCHAR(10) NOT NULL,
+			LO INT,
+			HI INT,
+			CONSTRAINT PK_TEST PRIMARY KEY (ID),
+			CONSTRAINT UQ_TEST_CODE UNIQUE (CODE),
+			CONSTRAINT CK_TEST_RANGE CHECK (LO <= HI));`
+
+	db, err := sql.Open("firebirdsql_createdb", "sysdba:masterkey@localhost:3050/tmp/fbx_test_constraints.fdb")
+	if err != nil {
+		t.Fatalf("Error creating database: %s", err)
+	}
+	defer db.Close()
+
+	err = ExecScript(db, sqlSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	constraints, err := Constraints(db, "TEST")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	byType := make(map[string][]*Constraint)
+	for _, c := range constraints {
+		byType[c.Type] = append(byType[c.Type], c)
+	}
+	if len(byType["PRIMARY KEY"]) != 1 || byType["PRIMARY KEY"][0].Name != "PK_TEST" {
+		t.Errorf("Expected primary key PK_TEST, got %v", byType["PRIMARY KEY"])
+	}
+	if len(byType["UNIQUE"]) != 1 {
+		t.Fatalf("Expected %d unique constraints, got %d", 1, len(byType["UNIQUE"]))
+	}
+	if uq := byType["UNIQUE"][0]; !reflect.DeepEqual([]string{"CODE"}, uq.Columns) || uq.IndexName == "" {
+		t.Errorf("Expected UNIQUE on [CODE] with an index, got %v on %q", uq.Columns, uq.IndexName)
+	}
+	if len(byType["NOT NULL"]) != 2 {
+		t.Errorf("Expected %d not null constraints, got %d", 2, len(byType["NOT NULL"]))
+	}
+	if len(byType["CHECK"]) != 1 {
+		t.Fatalf("Expected %d check constraints, got %d", 1, len(byType["CHECK"]))
+	}
+	ck := byType["CHECK"][0]
+	if ck.Name != "CK_TEST_RANGE" {
+		t.Errorf("Expected Name <%s>, got <%s>", "CK_TEST_RANGE", ck.Name)
+	}
+	if !ck.Source.Valid || ck.Source.String != "CHECK (LO <= HI)" {
+		t.Errorf("Expected Source <%s>, got %#v", "CHECK (LO <= HI)", ck.Source)
+	}
+	if !reflect.DeepEqual([]string{"HI", "LO"}, ck.Columns) {
+		t.Errorf("Expected Columns %v, got %v", []string{"HI", "LO"}, ck.Columns)
+	}
+}
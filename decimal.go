@@ -0,0 +1,59 @@
+package fbx
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// Decimal holds an exact decimal value as text, such as "-12.50". It scans
+// whatever representation the driver uses for NUMERIC, DECIMAL, INT128 and
+// DECFLOAT values: strings, integers, floats or types with a String method.
+type Decimal string
+
+func (d *Decimal) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		return errors.New("fbx: cannot scan NULL into Decimal")
+	case string:
+		*d = Decimal(v)
+	case []byte:
+		*d = Decimal(v)
+	case int64:
+		*d = Decimal(strconv.FormatInt(v, 10))
+	case float64:
+		*d = Decimal(strconv.FormatFloat(v, 'f', -1, 64))
+	case fmt.Stringer:
+		*d = Decimal(v.String())
+	default:
+		return fmt.Errorf("fbx: cannot scan %T into Decimal", src)
+	}
+	return nil
+}
+
+func (d Decimal) Value() (driver.Value, error) {
+	return string(d), nil
+}
+
+// NullDecimal is a Decimal that may be NULL.
+type NullDecimal struct {
+	Decimal Decimal
+	Valid   bool
+}
+
+func (n *NullDecimal) Scan(src interface{}) error {
+	if src == nil {
+		n.Decimal, n.Valid = "", false
+		return nil
+	}
+	n.Valid = true
+	return n.Decimal.Scan(src)
+}
+
+func (n NullDecimal) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return string(n.Decimal), nil
+}
@@ -0,0 +1,47 @@
+package fbx
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestDecimalScan(t *testing.T) {
+	tests := []struct {
+		src interface{}
+		exp Decimal
+	}{
+		{"12.50", "12.50"},
+		{[]byte("-0.01"), "-0.01"},
+		{int64(42), "42"},
+		{float64(1234.5), "1234.5"},
+		{big.NewInt(-170141183460469231), "-170141183460469231"},
+	}
+	for _, tt := range tests {
+		var d Decimal
+		if err := d.Scan(tt.src); err != nil || d != tt.exp {
+			t.Errorf("%#v: expected <%s>, got <%s> %v", tt.src, tt.exp, d, err)
+		}
+		var n NullDecimal
+		if err := n.Scan(tt.src); err != nil || !n.Valid || n.Decimal != tt.exp {
+			t.Errorf("%#v: expected valid <%s>, got %#v %v", tt.src, tt.exp, n, err)
+		}
+	}
+
+	var d Decimal
+	if err := d.Scan(nil); err == nil {
+		t.Error("Expected error scanning NULL into Decimal")
+	}
+	if err := d.Scan(true); err == nil {
+		t.Error("Expected error scanning bool into Decimal")
+	}
+	n := NullDecimal{"1", true}
+	if err := n.Scan(nil); err != nil || n.Valid {
+		t.Errorf("Expected NULL, got %#v %v", n, err)
+	}
+	if v, err := n.Value(); v != nil || err != nil {
+		t.Errorf("Expected nil value, got %v %v", v, err)
+	}
+	if v, err := Decimal("9.99").Value(); v != "9.99" || err != nil {
+		t.Errorf("Expected <9.99>, got %v %v", v, err)
+	}
+}
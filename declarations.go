@@ -0,0 +1,441 @@
+package fbx
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// bytesPerCharacter lists the character sets whose characters take more
+// than one byte.
+var bytesPerCharacter = map[string]int{
+	"UTF8":        4,
+	"UNICODE_FSS": 3,
+	"GB18030":     4,
+	"SJIS_0208":   2,
+	"EUCJ_0208":   2,
+	"KSC_5601":    2,
+	"BIG_5":       2,
+	"GB_2312":     2,
+	"GBK":         2,
+	"CP943C":      2,
+}
+
+const defaultSegmentSize = 80
+
+// Declaration renders the column's type as it would be written in a
+// Firebird column or domain definition, such as NUMERIC(9,2) or
+// VARCHAR(26) CHARACTER SET UTF8.
+func (c *Column) Declaration() string {
+	var b strings.Builder
+	switch c.SqlType {
+	case "CHAR", "VARCHAR":
+		length := c.CharacterLength.Int64
+		if !c.CharacterLength.Valid {
+			length = int64(c.Length)
+		}
+		fmt.Fprintf(&b, "%s(%d)", c.SqlType, length)
+	case "NUMERIC", "DECIMAL":
+		fmt.Fprintf(&b, "%s(%d,%d)", c.SqlType, c.numericPrecision(), -c.Scale)
+	case "DECFLOAT":
+		fmt.Fprintf(&b, "DECFLOAT(%d)", c.Precision.Int64)
+	case "BLOB":
+		b.WriteString("BLOB")
+		switch c.SqlSubtype.Int64 {
+		case 0:
+		case 1:
+			b.WriteString(" SUB_TYPE TEXT")
+		default:
+			fmt.Fprintf(&b, " SUB_TYPE %d", c.SqlSubtype.Int64)
+		}
+		if c.SegmentSize.Valid && c.SegmentSize.Int64 != defaultSegmentSize {
+			fmt.Fprintf(&b, " SEGMENT SIZE %d", c.SegmentSize.Int64)
+		}
+	default:
+		b.WriteString(c.SqlType)
+	}
+	if len(c.Dimensions) > 0 {
+		dims := make([]string, len(c.Dimensions))
+		for i, dim := range c.Dimensions {
+			if dim.Lower == 1 {
+				dims[i] = strconv.Itoa(dim.Upper)
+			} else {
+				dims[i] = fmt.Sprintf("%d:%d", dim.Lower, dim.Upper)
+			}
+		}
+		fmt.Fprintf(&b, "[%s]", strings.Join(dims, ", "))
+	}
+	if c.CharacterSet.Valid {
+		fmt.Fprintf(&b, " CHARACTER SET %s", c.CharacterSet.String)
+		// A collation named after its character set is the default one.
+		if c.Collation.Valid && c.Collation.String != c.CharacterSet.String {
+			fmt.Fprintf(&b, " COLLATE %s", c.Collation.String)
+		}
+	}
+	return b.String()
+}
+
+// numericPrecision falls back on the precision implied by the storage size
+// for columns created without one, as in dialect 1 databases.
+func (c *Column) numericPrecision() int64 {
+	if c.Precision.Int64 > 0 {
+		return c.Precision.Int64
+	}
+	switch c.Length {
+	case 2:
+		return 4
+	case 4:
+		return 9
+	case 8:
+		return 18
+	}
+	return 38
+}
+
+var declarationPattern = regexp.MustCompile(`(?is)^\s*(DOUBLE\s+PRECISION|TIMESTAMP\s+WITH\s+TIME\s+ZONE|TIMESTAMP\s+WITHOUT\s+TIME\s+ZONE|TIMESTAMP|` +
+	`TIME\s+WITH\s+TIME\s+ZONE|TIME\s+WITHOUT\s+TIME\s+ZONE|TIME|CHARACTER\s+VARYING|CHAR\s+VARYING|VARCHAR|CHARACTER|CHAR|` +
+	`SMALLINT|INTEGER|INT128|INT|BIGINT|FLOAT|NUMERIC|DECIMAL|DEC|DECFLOAT|DATE|BOOLEAN|BLOB)\b` +
+	`\s*(\([^)]*\))?\s*(?:\[([^\]]*)\])?(.*)$`)
+
+var typeAliases = map[string]string{
+	"CHARACTER":                   "CHAR",
+	"CHARACTER VARYING":           "VARCHAR",
+	"CHAR VARYING":                "VARCHAR",
+	"INT":                         "INTEGER",
+	"DEC":                         "DECIMAL",
+	"TIME WITHOUT TIME ZONE":      "TIME",
+	"TIMESTAMP WITHOUT TIME ZONE": "TIMESTAMP",
+}
+
+// Limits on declared sizes, in bytes where not stated otherwise.
+const (
+	maxCharLength    = 32767
+	maxVarcharLength = 32765
+	maxPrecision     = 38
+	maxSegmentSize   = 65535
+	maxDimensions    = 16
+)
+
+// ParseDeclaration parses a Firebird type declaration as rendered by
+// Column.Declaration into a Column with only its type fields set. The byte
+// Length of character types is only known when the character set is given.
+// The legacy BLOB(segment size, subtype) form is also accepted.
+func ParseDeclaration(decl string) (col *Column, err error) {
+	m := declarationPattern.FindStringSubmatch(decl)
+	if m == nil {
+		return nil, fmt.Errorf("fbx: invalid type declaration %q", decl)
+	}
+	col = &Column{SqlType: strings.ToUpper(strings.Join(strings.Fields(m[1]), " "))}
+	if alias, ok := typeAliases[col.SqlType]; ok {
+		col.SqlType = alias
+	}
+	invalid := func(format string, args ...interface{}) (*Column, error) {
+		return nil, fmt.Errorf("fbx: invalid type declaration %q: %s", decl, fmt.Sprintf(format, args...))
+	}
+
+	// args holds the parenthesized numbers; an omitted one, as in BLOB(, 1),
+	// is left unset.
+	var args []sql.NullInt64
+	if m[2] != "" {
+		for _, arg := range strings.Split(m[2][1:len(m[2])-1], ",") {
+			var n sql.NullInt64
+			if arg = strings.TrimSpace(arg); arg != "" {
+				if n.Int64, err = strconv.ParseInt(arg, 10, 32); err != nil {
+					return invalid("bad number %s", arg)
+				}
+				n.Valid = true
+			}
+			args = append(args, n)
+		}
+	}
+	switch {
+	case len(args) > 2:
+		return invalid("too many arguments")
+	case len(args) == 0:
+	case col.SqlType == "BLOB":
+	case !args[0].Valid || (len(args) == 2 && !args[1].Valid):
+		return invalid("missing argument")
+	}
+	hasSize := len(args) > 0 && args[0].Valid
+	var size, scale int64
+	if hasSize {
+		size = args[0].Int64
+	}
+	if len(args) == 2 {
+		scale = args[1].Int64
+	}
+	switch col.SqlType {
+	case "NUMERIC", "DECIMAL", "BLOB":
+	case "CHAR", "VARCHAR", "FLOAT", "DECFLOAT":
+		if len(args) > 1 {
+			return invalid("%s takes a single argument", col.SqlType)
+		}
+	default:
+		if len(args) > 0 {
+			return invalid("%s takes no arguments", col.SqlType)
+		}
+	}
+
+	integer := func(length int16) {
+		col.Length = length
+		col.SqlSubtype = sql.NullInt64{Int64: 0, Valid: true}
+		col.Precision = sql.NullInt64{Int64: 0, Valid: true}
+	}
+	switch col.SqlType {
+	case "SMALLINT":
+		integer(2)
+	case "INTEGER":
+		integer(4)
+	case "BIGINT":
+		integer(8)
+	case "INT128":
+		integer(16)
+	case "NUMERIC", "DECIMAL":
+		if !hasSize {
+			size = 9
+		}
+		if size < 1 || size > maxPrecision {
+			return invalid("precision %d out of range 1 to %d", size, maxPrecision)
+		}
+		if scale < 0 || scale > size {
+			return invalid("scale %d out of range 0 to %d", scale, size)
+		}
+		col.Precision = sql.NullInt64{Int64: size, Valid: true}
+		col.Scale = int16(-scale)
+		col.SqlSubtype = sql.NullInt64{Int64: 1, Valid: true}
+		if col.SqlType == "DECIMAL" {
+			col.SqlSubtype.Int64 = 2
+		}
+		// NUMERIC(1-4) is stored as SMALLINT, DECIMAL(1-4) as INTEGER.
+		switch {
+		case size <= 4 && col.SqlType == "NUMERIC":
+			col.Length = 2
+		case size <= 9:
+			col.Length = 4
+		case size <= 18:
+			col.Length = 8
+		default:
+			col.Length = 16
+		}
+	case "FLOAT":
+		if hasSize && (size < 1 || size > 53) {
+			return invalid("precision %d out of range 1 to 53", size)
+		}
+		col.Length = 4
+		if size > 7 {
+			col.SqlType = "DOUBLE PRECISION"
+			col.Length = 8
+		}
+	case "DOUBLE PRECISION":
+		col.Length = 8
+	case "DECFLOAT":
+		if !hasSize {
+			size = 34
+		}
+		if size != 16 && size != 34 {
+			return invalid("precision must be 16 or 34")
+		}
+		col.Precision = sql.NullInt64{Int64: size, Valid: true}
+		col.Length = 16
+		if size == 16 {
+			col.Length = 8
+		}
+	case "DATE", "TIME":
+		col.Length = 4
+	case "TIMESTAMP", "TIME WITH TIME ZONE":
+		col.Length = 8
+	case "TIMESTAMP WITH TIME ZONE":
+		col.Length = 12
+	case "BOOLEAN":
+		col.Length = 1
+	case "CHAR", "VARCHAR":
+		if !hasSize {
+			size = 1
+		}
+		if size < 1 {
+			return invalid("length must be positive")
+		}
+		col.CharacterLength = sql.NullInt64{Int64: size, Valid: true}
+		col.SqlSubtype = sql.NullInt64{Int64: 0, Valid: true}
+	case "BLOB":
+		col.Length = 8
+		col.SqlSubtype = sql.NullInt64{Int64: 0, Valid: true}
+		col.SegmentSize = sql.NullInt64{Int64: defaultSegmentSize, Valid: true}
+		if hasSize {
+			col.SegmentSize.Int64 = size
+		}
+		if len(args) == 2 {
+			col.SqlSubtype.Int64 = scale
+		}
+	}
+
+	if m[3] != "" {
+		if col.Dimensions, err = parseDimensions(m[3]); err != nil {
+			return nil, err
+		}
+	}
+	if err = col.parseTypeClauses(strings.Fields(m[4])); err != nil {
+		return invalid("%v", err)
+	}
+	if col.CharacterSet.Valid || col.Collation.Valid {
+		text := col.SqlType == "CHAR" || col.SqlType == "VARCHAR" || col.SqlType == "BLOB" && col.SqlSubtype.Int64 == 1
+		if !text {
+			return invalid("CHARACTER SET and COLLATE apply only to character types and text blobs")
+		}
+	}
+	if col.SqlType == "BLOB" {
+		if n := col.SegmentSize.Int64; n < 1 || n > maxSegmentSize {
+			return invalid("segment size %d out of range 1 to %d", n, maxSegmentSize)
+		}
+		if n := col.SqlSubtype.Int64; n < -32768 || n > 32767 {
+			return invalid("subtype %d out of range", n)
+		}
+	}
+	if col.SqlType == "CHAR" || col.SqlType == "VARCHAR" {
+		length := col.CharacterLength.Int64
+		if n, ok := bytesPerCharacter[col.CharacterSet.String]; ok {
+			length *= int64(n)
+		}
+		max := int64(maxCharLength)
+		if col.SqlType == "VARCHAR" {
+			max = maxVarcharLength
+		}
+		if length > max {
+			return invalid("length of %d bytes exceeds %d", length, max)
+		}
+		col.Length = int16(length)
+	}
+	col.TypeCode = typeCodeOf(col.SqlType, col.Length)
+	col.InternalSize = int(col.Length)
+	if col.TypeCode == blr_varying {
+		col.InternalSize += 2
+	}
+	if len(col.Dimensions) > 0 {
+		col.InternalSize = 8
+	}
+	return col, nil
+}
+
+// typeCodeOf is the inverse of sqlTypeFromCode, with the storage length
+// telling apart the integer types behind NUMERIC and DECIMAL.
+func typeCodeOf(sqlType string, length int16) int {
+	switch sqlType {
+	case "CHAR":
+		return blr_text
+	case "VARCHAR":
+		return blr_varying
+	case "SMALLINT":
+		return blr_short
+	case "INTEGER":
+		return blr_long
+	case "BIGINT":
+		return blr_int64
+	case "INT128":
+		return blr_int128
+	case "NUMERIC", "DECIMAL":
+		switch length {
+		case 2:
+			return blr_short
+		case 4:
+			return blr_long
+		case 8:
+			return blr_int64
+		}
+		return blr_int128
+	case "FLOAT":
+		return blr_float
+	case "DOUBLE PRECISION":
+		return blr_double
+	case "DECFLOAT":
+		if length == 8 {
+			return blr_dec64
+		}
+		return blr_dec128
+	case "DATE":
+		return blr_sql_date
+	case "TIME":
+		return blr_sql_time
+	case "TIMESTAMP":
+		return blr_timestamp
+	case "TIME WITH TIME ZONE":
+		return blr_sql_time_tz
+	case "TIMESTAMP WITH TIME ZONE":
+		return blr_timestamp_tz
+	case "BOOLEAN":
+		return blr_bool
+	case "BLOB":
+		return blr_blob
+	}
+	return 0
+}
+
+func (c *Column) parseTypeClauses(words []string) error {
+	for i := 0; i < len(words); i++ {
+		word := strings.ToUpper(words[i])
+		switch {
+		case word == "SUB_TYPE" && c.SqlType == "BLOB" && i+1 < len(words):
+			i++
+			switch subType := strings.ToUpper(words[i]); subType {
+			case "TEXT":
+				c.SqlSubtype.Int64 = 1
+			case "BINARY":
+				c.SqlSubtype.Int64 = 0
+			default:
+				n, err := strconv.ParseInt(subType, 10, 16)
+				if err != nil {
+					return fmt.Errorf("unknown blob subtype %s", words[i])
+				}
+				c.SqlSubtype.Int64 = n
+			}
+		case word == "SEGMENT" && c.SqlType == "BLOB" && i+2 < len(words) && strings.ToUpper(words[i+1]) == "SIZE":
+			n, err := strconv.ParseInt(words[i+2], 10, 32)
+			if err != nil {
+				return fmt.Errorf("invalid segment size %s", words[i+2])
+			}
+			c.SegmentSize.Int64 = n
+			i += 2
+		case word == "CHARACTER" && i+2 < len(words) && strings.ToUpper(words[i+1]) == "SET":
+			c.CharacterSet = sql.NullString{String: strings.ToUpper(words[i+2]), Valid: true}
+			if !c.Collation.Valid {
+				c.Collation = c.CharacterSet
+			}
+			i += 2
+		case word == "COLLATE" && i+1 < len(words):
+			i++
+			c.Collation = sql.NullString{String: strings.ToUpper(words[i]), Valid: true}
+		default:
+			return fmt.Errorf("unexpected %s", words[i])
+		}
+	}
+	return nil
+}
+
+func parseDimensions(s string) (dims []ArrayDimension, err error) {
+	parts := strings.Split(s, ",")
+	if len(parts) > maxDimensions {
+		return nil, fmt.Errorf("fbx: arrays have at most %d dimensions", maxDimensions)
+	}
+	for _, part := range parts {
+		bounds := strings.Split(part, ":")
+		if len(bounds) > 2 {
+			return nil, fmt.Errorf("fbx: invalid array dimension %q", part)
+		}
+		var n [2]int64
+		for i, bound := range bounds {
+			if n[i], err = strconv.ParseInt(strings.TrimSpace(bound), 10, 32); err != nil {
+				return nil, fmt.Errorf("fbx: invalid array dimension %q", part)
+			}
+		}
+		dim := ArrayDimension{Lower: 1, Upper: int(n[0])}
+		if len(bounds) == 2 {
+			dim = ArrayDimension{Lower: int(n[0]), Upper: int(n[1])}
+		}
+		if dim.Upper < dim.Lower {
+			return nil, fmt.Errorf("fbx: invalid array dimension %q", part)
+		}
+		dims = append(dims, dim)
+	}
+	return
+}
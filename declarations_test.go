@@ -0,0 +1,168 @@
+package fbx
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+)
+
+var expectedDeclarations = []string{
+	"BIGINT",
+	"INTEGER",
+	"BLOB",
+	"INTEGER",
+	"INTEGER",
+	"BIGINT",
+	"FLOAT",
+	"DOUBLE PRECISION",
+	"CHAR(1) CHARACTER SET UTF8",
+	"CHAR(26) CHARACTER SET UTF8",
+	"VARCHAR(1) CHARACTER SET UTF8",
+	"VARCHAR(26) CHARACTER SET UTF8",
+	"BLOB SUB_TYPE TEXT CHARACTER SET UTF8",
+	"DATE",
+	"TIME",
+	"TIMESTAMP",
+	"NUMERIC(9,2)",
+	"DECIMAL(9,2)",
+}
+
+// typeOnly strips the fields of a column that are not part of its type.
+func typeOnly(col Column) *Column {
+	col.Name, col.Domain = "", ""
+	col.Default, col.Nullable = sql.NullString{}, sql.NullBool{}
+	col.Computed, col.Identity, col.Generator = sql.NullString{}, "", ""
+	return &col
+}
+
+func TestDeclaration(t *testing.T) {
+	for i, col := range expectedColumns {
+		if got := col.Declaration(); got != expectedDeclarations[i] {
+			t.Errorf("%s: expected <%s>, got <%s>", col.Name, expectedDeclarations[i], got)
+		}
+	}
+
+	tests := []struct {
+		col Column
+		exp string
+	}{
+		{Column{SqlType: "VARCHAR", CharacterLength: sql.NullInt64{10, true}, CharacterSet: sql.NullString{"UTF8", true}, Collation: sql.NullString{"UNICODE_CI", true}},
+			"VARCHAR(10) CHARACTER SET UTF8 COLLATE UNICODE_CI"},
+		{Column{SqlType: "BLOB", SqlSubtype: sql.NullInt64{1, true}, SegmentSize: sql.NullInt64{200, true}, CharacterSet: sql.NullString{"WIN1252", true}, Collation: sql.NullString{"WIN1252", true}},
+			"BLOB SUB_TYPE TEXT SEGMENT SIZE 200 CHARACTER SET WIN1252"},
+		{Column{SqlType: "BLOB", SqlSubtype: sql.NullInt64{-1, true}}, "BLOB SUB_TYPE -1"},
+		{Column{SqlType: "INTEGER", Dimensions: []ArrayDimension{{1, 3}, {0, 4}}}, "INTEGER[3, 0:4]"},
+		{Column{SqlType: "CHAR", CharacterLength: sql.NullInt64{2, true}, Dimensions: []ArrayDimension{{1, 5}}, CharacterSet: sql.NullString{"OCTETS", true}},
+			"CHAR(2)[5] CHARACTER SET OCTETS"},
+		{Column{SqlType: "NUMERIC", Length: 8, Scale: -4}, "NUMERIC(18,4)"},
+		{Column{SqlType: "DECFLOAT", Precision: sql.NullInt64{16, true}}, "DECFLOAT(16)"},
+	}
+	for _, tt := range tests {
+		if got := tt.col.Declaration(); got != tt.exp {
+			t.Errorf("Expected <%s>, got <%s>", tt.exp, got)
+		}
+	}
+}
+
+func TestParseDeclaration(t *testing.T) {
+	for i, decl := range expectedDeclarations {
+		col, err := ParseDeclaration(decl)
+		if err != nil {
+			t.Errorf("%s: %s", decl, err)
+			continue
+		}
+		if exp := typeOnly(expectedColumns[i]); !reflect.DeepEqual(exp, col) {
+			t.Errorf("Expected %#v,\n got %#v", exp, col)
+		}
+	}
+
+	tests := []struct {
+		decl string
+		exp  string
+	}{
+		{"varchar(10) character set utf8 collate unicode_ci", "VARCHAR(10) CHARACTER SET UTF8 COLLATE UNICODE_CI"},
+		{"CHARACTER VARYING(5)", "VARCHAR(5)"},
+		{"CHARACTER", "CHAR(1)"},
+		{"INT", "INTEGER"},
+		{"DEC(5, 1)", "DECIMAL(5,1)"},
+		{"NUMERIC", "NUMERIC(9,0)"},
+		{"FLOAT(20)", "DOUBLE PRECISION"},
+		{"TIMESTAMP WITHOUT TIME ZONE", "TIMESTAMP"},
+		{"TIME  WITH TIME ZONE", "TIME WITH TIME ZONE"},
+		{"DECFLOAT", "DECFLOAT(34)"},
+		{"BLOB SUB_TYPE BINARY SEGMENT SIZE 80", "BLOB"},
+		{"BLOB SUB_TYPE 1 SEGMENT SIZE 4096", "BLOB SUB_TYPE TEXT SEGMENT SIZE 4096"},
+		{"INTEGER [ 0:4, 2 ]", "INTEGER[0:4, 2]"},
+		{"CHAR(2) [5] CHARACTER SET OCTETS", "CHAR(2)[5] CHARACTER SET OCTETS"},
+		{"BLOB(200, 1)", "BLOB SUB_TYPE TEXT SEGMENT SIZE 200"},
+		{"BLOB(4096)", "BLOB SEGMENT SIZE 4096"},
+		{"BLOB(, 1) CHARACTER SET WIN1252", "BLOB SUB_TYPE TEXT CHARACTER SET WIN1252"},
+		{"VARCHAR(8191) CHARACTER SET UTF8", "VARCHAR(8191) CHARACTER SET UTF8"},
+		{"VARCHAR(32765)", "VARCHAR(32765)"},
+		{"NUMERIC(38,38)", "NUMERIC(38,38)"},
+	}
+	for _, tt := range tests {
+		col, err := ParseDeclaration(tt.decl)
+		if err != nil {
+			t.Errorf("%s: %s", tt.decl, err)
+			continue
+		}
+		if got := col.Declaration(); got != tt.exp {
+			t.Errorf("%s: expected <%s>, got <%s>", tt.decl, tt.exp, got)
+		}
+	}
+
+	if col, _ := ParseDeclaration("NUMERIC(4,2)"); col.Length != 2 {
+		t.Errorf("Expected NUMERIC(4,2) length <2>, got <%d>", col.Length)
+	}
+	if col, _ := ParseDeclaration("DECIMAL(4,2)"); col.Length != 4 {
+		t.Errorf("Expected DECIMAL(4,2) length <4>, got <%d>", col.Length)
+	}
+
+	for _, decl := range []string{
+		"", "VARCHAR2(10)", "INTEGER NOT NULL", "INTEGER[1:2:3]", "BLOB SUB_TYPE IMAGE",
+		"VARCHAR(10000) CHARACTER SET UTF8", "VARCHAR(99999)", "VARCHAR(32766)", "CHAR(32768)", "CHAR(0)",
+		"VARCHAR(99999999999999999999)", "VARCHAR(10, 2)", "VARCHAR()",
+		"NUMERIC(4,20)", "NUMERIC(0)", "NUMERIC(39,2)", "DECIMAL(,2)", "DECFLOAT(20)", "FLOAT(54)",
+		"INTEGER(5)", "DATE(1)", "BLOB(0)", "BLOB(70000)", "BLOB(80, 40000)", "BLOB(1, 2, 3)",
+		"BLOB SEGMENT SIZE 0", "BLOB SUB_TYPE 99999", "INTEGER[3:1]", "INTEGER[99999999999]",
+		"INTEGER[1,2,3,4,5,6,7,8,9,10,11,12,13,14,15,16,17]",
+		"INTEGER CHARACTER SET UTF8", "BLOB SUB_TYPE BINARY CHARACTER SET UTF8", "NUMERIC(9,2) COLLATE UNICODE",
+		"BLOB SUB_TYPE -1 COLLATE UTF8", "BLOB(80) CHARACTER SET UTF8",
+	} {
+		if _, err := ParseDeclaration(decl); err == nil {
+			t.Errorf("%q: expected error", decl)
+		}
+	}
+}
+
+func TestDeclarationRoundTrip(t *testing.T) {
+	db, err := sql.Open("firebirdsql_createdb", "sysdba:masterkey@localhost:3050/tmp/fbx_test_declaration_round_trip.fdb")
+	if err != nil {
+		t.Fatalf("Error creating database: %s", err)
+	}
+	defer db.Close()
+
+	err = ExecScript(db, sqlSampleSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	relations, err := AllColumns(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for relationName, cols := range relations {
+		for _, col := range cols {
+			decl := col.Declaration()
+			parsed, err := ParseDeclaration(decl)
+			if err != nil {
+				t.Errorf("%s.%s: %s", relationName, col.Name, err)
+				continue
+			}
+			if exp := typeOnly(*col); !reflect.DeepEqual(exp, parsed) {
+				t.Errorf("%s.%s: expected %#v,\n got %#v", relationName, col.Name, exp, parsed)
+			}
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package fbx
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Directive is an isql client-side command found in a script, such as
+// SET SQL DIALECT or CREATE DATABASE. Name holds its keywords in canonical
+// form and Args the remainder of the statement text. EXIT and QUIT also
+// end the script after the handler has seen them.
+type Directive struct {
+	Statement
+	Name string
+	Args string
+}
+
+// DirectiveHandler interprets a directive on behalf of ExecScript.
+// Returning an error fails the directive's statement.
+type DirectiveHandler func(d Directive) error
+
+var directivePattern = regexp.MustCompile(`(?is)^(SET\s+SQL\s+DIALECT|SET\s+NAMES|SET\s+AUTODDL|SET\s+AUTO|` +
+	`SET\s+(?:BAIL|BLOBDISPLAY|BLOB|COUNT|ECHO|EXEC_PATH_DISPLAY|EXPLAIN|HEADING|KEEP_TRAN_PARAMS|KEEP_TRAN|LIST|` +
+	`LOCAL_TIMEOUT|MAXROWS|PER_TABLE_STATS|PER_TAB|PLANONLY|PLAN|ROWCOUNT|SQLDA_DISPLAY|STATS|TIME|WARNINGS|WNG|WIDTH)|` +
+	`CREATE\s+DATABASE|CREATE\s+SCHEMA|DROP\s+DATABASE|CONNECT|SHOW|OUTPUT|OUT|EXIT|QUIT|` +
+	`EDIT|HELP|SHELL|BLOBDUMP|BLOBVIEW|COPY|ADD)\b\s*(.*)$`)
+
+var directiveAliases = map[string]string{
+	"SET AUTO":      "SET AUTODDL",
+	"CREATE SCHEMA": "CREATE DATABASE",
+	"OUT":           "OUTPUT",
+}
+
+// ParseDirective reports whether stmt is an isql directive rather than
+// a statement for the server.
+func ParseDirective(stmt Statement) (d Directive, ok bool) {
+	m := directivePattern.FindStringSubmatch(stmt.Text)
+	if m == nil {
+		return
+	}
+	name := strings.ToUpper(strings.Join(strings.Fields(m[1]), " "))
+	if name == "SET TIME" && strings.HasPrefix(strings.ToUpper(m[2]), "ZONE") {
+		// SET TIME ZONE is a server statement.
+		return
+	}
+	if alias, found := directiveAliases[name]; found {
+		name = alias
+	}
+	return Directive{Statement: stmt, Name: name, Args: m[2]}, true
+}
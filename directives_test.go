@@ -0,0 +1,51 @@
+package fbx
+
+import (
+	"testing"
+)
+
+func TestParseDirective(t *testing.T) {
+	tests := []struct {
+		text string
+		ok   bool
+		name string
+		args string
+	}{
+		{"SET SQL DIALECT 3", true, "SET SQL DIALECT", "3"},
+		{"set names  UTF8", true, "SET NAMES", "UTF8"},
+		{"SET AUTODDL ON", true, "SET AUTODDL", "ON"},
+		{"SET AUTO OFF", true, "SET AUTODDL", "OFF"},
+		{"CREATE DATABASE 'localhost:/tmp/x.fdb' PAGE_SIZE 8192\nDEFAULT CHARACTER SET UTF8", true, "CREATE DATABASE", "'localhost:/tmp/x.fdb' PAGE_SIZE 8192\nDEFAULT CHARACTER SET UTF8"},
+		{"CREATE SCHEMA 'x.fdb'", true, "CREATE DATABASE", "'x.fdb'"},
+		{"CONNECT 'x.fdb' USER 'SYSDBA' PASSWORD 'masterkey'", true, "CONNECT", "'x.fdb' USER 'SYSDBA' PASSWORD 'masterkey'"},
+		{"SET LIST ON", true, "SET LIST", "ON"},
+		{"SET TIME ON", true, "SET TIME", "ON"},
+		{"SHOW TABLES", true, "SHOW", "TABLES"},
+		{"EXIT", true, "EXIT", ""},
+		{"quit", true, "QUIT", ""},
+		{"HELP SET", true, "HELP", "SET"},
+		{"SHELL ls -l", true, "SHELL", "ls -l"},
+		{"BLOBDUMP 32:d48 'x.bin'", true, "BLOBDUMP", "32:d48 'x.bin'"},
+		{"BLOBVIEW 32:d48", true, "BLOBVIEW", "32:d48"},
+		{"EDIT schema.sql", true, "EDIT", "schema.sql"},
+		{"COPY TEST TEST2 'other.fdb'", true, "COPY", "TEST TEST2 'other.fdb'"},
+		{"ADD TEST", true, "ADD", "TEST"},
+		{"EXITS", false, "", ""},
+		{"SET TIME ZONE 'UTC'", false, "", ""},
+		{"SET STATISTICS INDEX PK_TEST", false, "", ""},
+		{"SET GENERATOR TEST_SEQ TO 10", false, "", ""},
+		{"SET TRANSACTION READ COMMITTED", false, "", ""},
+		{"CREATE TABLE CONNECTIONS (ID INT)", false, "", ""},
+		{"CONNECTIONS", false, "", ""},
+	}
+	for _, tt := range tests {
+		d, ok := ParseDirective(Statement{Text: tt.text})
+		if ok != tt.ok {
+			t.Errorf("%q: expected ok <%v>, got <%v>", tt.text, tt.ok, ok)
+			continue
+		}
+		if d.Name != tt.name || d.Args != tt.args {
+			t.Errorf("%q: expected <%s> <%s>, got <%s> <%s>", tt.text, tt.name, tt.args, d.Name, d.Args)
+		}
+	}
+}
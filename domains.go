@@ -0,0 +1,153 @@
+package fbx
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"unicode"
+)
+
+// Domain describes a user-defined domain. Check holds the source of its
+// CHECK constraint and Dimensions the bounds of an array domain.
+type Domain struct {
+	Name         string
+	SqlType      string
+	SqlSubtype   sql.NullInt64
+	Length       int16
+	Precision    sql.NullInt64
+	Scale        int16
+	CharacterSet sql.NullString
+	Collation    sql.NullString
+	Default      sql.NullString
+	NotNull      bool
+	Check        sql.NullString
+	Dimensions   []ArrayDimension
+	Columns      []ColumnRef
+}
+
+// ArrayDimension holds the inclusive bounds of one dimension of an array.
+type ArrayDimension struct {
+	Lower int
+	Upper int
+}
+
+// ColumnRef identifies a column of a table or view.
+type ColumnRef struct {
+	TableName string
+	Name      string
+}
+
+// Domains returns the user-defined domains with the columns that use them.
+func Domains(db Querier) (domains []*Domain, err error) {
+	return DomainsContext(context.Background(), db)
+}
+
+func DomainsContext(ctx context.Context, db Querier) (domains []*Domain, err error) {
+	const query = `
+		SELECT f.rdb$field_name, f.rdb$field_type, f.rdb$field_sub_type,
+			f.rdb$field_length, f.rdb$field_precision, f.rdb$field_scale,
+			cs.rdb$character_set_name, co.rdb$collation_name,
+			f.rdb$default_source, f.rdb$null_flag, f.rdb$validation_source
+		FROM rdb$fields f
+		LEFT JOIN rdb$character_sets cs ON f.rdb$character_set_id = cs.rdb$character_set_id
+		LEFT JOIN rdb$collations co ON f.rdb$collation_id = co.rdb$collation_id
+			AND f.rdb$character_set_id = co.rdb$character_set_id
+		WHERE (f.rdb$system_flag <> 1 OR f.rdb$system_flag IS NULL)
+			AND f.rdb$field_name NOT STARTING WITH 'RDB$'
+		ORDER BY f.rdb$field_name`
+
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	byName := make(map[string]*Domain)
+	for rows.Next() {
+		var d Domain
+		var sqlType int16
+		var notNull sql.NullInt64
+		if err = rows.Scan(
+			&d.Name,
+			&sqlType,
+			&d.SqlSubtype,
+			&d.Length,
+			&d.Precision,
+			&d.Scale,
+			&d.CharacterSet,
+			&d.Collation,
+			&d.Default,
+			&notNull,
+			&d.Check); err != nil {
+			return
+		}
+		d.Name = strings.TrimRightFunc(d.Name, unicode.IsSpace)
+		d.SqlType = sqlTypeFromCode(int(sqlType), int(d.SqlSubtype.Int64))
+		d.CharacterSet.String = strings.TrimRightFunc(d.CharacterSet.String, unicode.IsSpace)
+		d.Collation.String = strings.TrimRightFunc(d.Collation.String, unicode.IsSpace)
+		if d.Default.Valid {
+			d.Default.String = strings.Replace(d.Default.String, "DEFAULT ", "", 1)
+			d.Default.String = strings.TrimLeftFunc(d.Default.String, unicode.IsSpace)
+		}
+		d.NotNull = notNull.Int64 == 1
+		if d.Check.Valid {
+			d.Check.String = strings.TrimSpace(d.Check.String)
+		}
+		byName[d.Name] = &d
+		domains = append(domains, &d)
+	}
+	if err = rows.Err(); err != nil {
+		return
+	}
+	rows.Close()
+
+	const dimensionsQuery = `
+		SELECT d.rdb$field_name, d.rdb$lower_bound, d.rdb$upper_bound
+		FROM rdb$field_dimensions d
+		WHERE d.rdb$field_name NOT STARTING WITH 'RDB$'
+		ORDER BY d.rdb$field_name, d.rdb$dimension`
+
+	if rows, err = db.QueryContext(ctx, dimensionsQuery); err != nil {
+		return
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		var dim ArrayDimension
+		if err = rows.Scan(&name, &dim.Lower, &dim.Upper); err != nil {
+			return
+		}
+		if d, ok := byName[strings.TrimRightFunc(name, unicode.IsSpace)]; ok {
+			d.Dimensions = append(d.Dimensions, dim)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return
+	}
+	rows.Close()
+
+	const columnsQuery = `
+		SELECT r.rdb$field_source, r.rdb$relation_name, r.rdb$field_name
+		FROM rdb$relation_fields r
+		WHERE r.rdb$field_source NOT STARTING WITH 'RDB$'
+		ORDER BY r.rdb$field_source, r.rdb$relation_name, r.rdb$field_position`
+
+	if rows, err = db.QueryContext(ctx, columnsQuery); err != nil {
+		return
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		var col ColumnRef
+		if err = rows.Scan(&name, &col.TableName, &col.Name); err != nil {
+			return
+		}
+		if d, ok := byName[strings.TrimRightFunc(name, unicode.IsSpace)]; ok {
+			col.TableName = strings.TrimRightFunc(col.TableName, unicode.IsSpace)
+			col.Name = strings.TrimRightFunc(col.Name, unicode.IsSpace)
+			d.Columns = append(d.Columns, col)
+		}
+	}
+	err = rows.Err()
+	return
+}
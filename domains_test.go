@@ -0,0 +1,74 @@
+package fbx
+
+import (
+	"database/sql"
+	_ "github.com/rowland/firebirdsql"
+	"reflect"
+	"testing"
+)
+
+func TestDomains(t *testing.T) {
+	const sqlSchema = `
+		CREATE DOMAIN AMOUNT NUMERIC(15,2) DEFAULT 0 NOT NULL CHECK (VALUE >= 0);
+		CREATE DOMAIN CODE VARCHAR(10) CHARACTER SET WIN1252 COLLATE PXW_INTL;
+		CREATE DOMAIN MATRIX INTEGER[3, 0:1];
+		CREATE TABLE ORDERS (ID INT NOT NULL, TOTAL AMOUNT, TAX AMOUNT, REF CODE);
+		CREATE TABLE ITEMS (ID INT NOT NULL, PRICE AMOUNT);`
+
+	db, err := sql.Open("firebirdsql_createdb", "sysdba:masterkey@localhost:3050/tmp/fbx_test_domains.fdb")
+	if err != nil {
+		t.Fatalf("Error creating database: %s", err)
+	}
+	defer db.Close()
+
+	err = ExecScript(db, sqlSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	domains, err := Domains(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(domains) != 3 {
+		t.Fatalf("Expected %d domains, got %d", 3, len(domains))
+	}
+
+	amount := domains[0]
+	if amount.Name != "AMOUNT" || amount.SqlType != "NUMERIC" {
+		t.Errorf("Expected AMOUNT NUMERIC, got %s %s", amount.Name, amount.SqlType)
+	}
+	if amount.Precision.Int64 != 15 || amount.Scale != -2 {
+		t.Errorf("Expected precision <15> and scale <-2>, got <%d> and <%d>", amount.Precision.Int64, amount.Scale)
+	}
+	if !amount.NotNull {
+		t.Error("Expected AMOUNT to be NOT NULL")
+	}
+	if amount.Default.String != "0" {
+		t.Errorf("Expected Default <0>, got <%s>", amount.Default.String)
+	}
+	if amount.Check.String != "CHECK (VALUE >= 0)" {
+		t.Errorf("Expected Check <%s>, got <%s>", "CHECK (VALUE >= 0)", amount.Check.String)
+	}
+	expColumns := []ColumnRef{{"ITEMS", "PRICE"}, {"ORDERS", "TOTAL"}, {"ORDERS", "TAX"}}
+	if !reflect.DeepEqual(expColumns, amount.Columns) {
+		t.Errorf("Expected %v, got %v", expColumns, amount.Columns)
+	}
+
+	code := domains[1]
+	if code.CharacterSet.String != "WIN1252" || code.Collation.String != "PXW_INTL" {
+		t.Errorf("Expected WIN1252 / PXW_INTL, got %s / %s", code.CharacterSet.String, code.Collation.String)
+	}
+	if code.Length != 10 {
+		t.Errorf("Expected Length <10>, got <%d>", code.Length)
+	}
+
+	matrix := domains[2]
+	expDimensions := []ArrayDimension{{1, 3}, {0, 1}}
+	if !reflect.DeepEqual(expDimensions, matrix.Dimensions) {
+		t.Errorf("Expected %v, got %v", expDimensions, matrix.Dimensions)
+	}
+	if len(matrix.Columns) != 0 {
+		t.Errorf("Expected no columns, got %v", matrix.Columns)
+	}
+}
@@ -0,0 +1,57 @@
+package fbx
+
+import (
+	"context"
+	"database/sql"
+	"runtime"
+	"sync"
+	"weak"
+)
+
+type fieldKey struct {
+	relationName, fieldName string
+}
+
+// hasFieldCache maps a weak *sql.DB, which stays bound to one database, to
+// a *sync.Map of the probes made through it. The entry is dropped once the
+// *sql.DB is collected. Transactions and connections are short-lived and
+// are probed each time.
+var hasFieldCache sync.Map
+
+// hasField reports whether a system relation has the given field, for
+// metadata that only exists in newer Firebird versions.
+func hasField(ctx context.Context, db Querier, relationName, fieldName string) (ok bool, err error) {
+	const query = `SELECT COUNT(*) FROM RDB$RELATION_FIELDS
+		WHERE RDB$RELATION_NAME = ? AND RDB$FIELD_NAME = ?`
+	var probes *sync.Map
+	if sqlDB, cacheable := db.(*sql.DB); cacheable {
+		probes = fieldProbes(sqlDB)
+	}
+	key := fieldKey{relationName, fieldName}
+	if probes != nil {
+		if cached, found := probes.Load(key); found {
+			return cached.(bool), nil
+		}
+	}
+	var count int
+	if err = db.QueryRowContext(ctx, query, relationName, fieldName).Scan(&count); err != nil {
+		return
+	}
+	ok = count > 0
+	if probes != nil {
+		probes.Store(key, ok)
+	}
+	return
+}
+
+func fieldProbes(db *sql.DB) *sync.Map {
+	wp := weak.Make(db)
+	if probes, found := hasFieldCache.Load(wp); found {
+		return probes.(*sync.Map)
+	}
+	probes, loaded := hasFieldCache.LoadOrStore(wp, new(sync.Map))
+	if !loaded {
+		runtime.AddCleanup(db, func(wp weak.Pointer[sql.DB]) { hasFieldCache.Delete(wp) }, wp)
+	}
+	return probes.(*sync.Map)
+}
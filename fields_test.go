@@ -0,0 +1,61 @@
+package fbx
+
+import (
+	"context"
+	"database/sql"
+	_ "github.com/rowland/firebirdsql"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+	"weak"
+)
+
+func TestHasFieldCached(t *testing.T) {
+	db, err := sql.Open("firebirdsql_createdb", "sysdba:masterkey@localhost:3050/tmp/fbx_test_has_field_cached.fdb")
+	if err != nil {
+		t.Fatalf("Error creating database: %s", err)
+	}
+
+	for _, exp := range []bool{true, true} {
+		ok, err := hasField(context.Background(), db, "RDB$INDICES", "RDB$INDEX_NAME")
+		if err != nil || ok != exp {
+			t.Fatalf("Expected <%v>, got <%v> and %v", exp, ok, err)
+		}
+	}
+	if ok, err := hasField(context.Background(), db, "RDB$INDICES", "NO_SUCH_FIELD"); err != nil || ok {
+		t.Fatalf("Expected <false>, got <%v> and %v", ok, err)
+	}
+
+	wp := weak.Make(db)
+	probes, found := hasFieldCache.Load(wp)
+	if !found {
+		t.Fatal("Expected probes to be cached")
+	}
+	for key, exp := range map[fieldKey]bool{
+		{"RDB$INDICES", "RDB$INDEX_NAME"}: true,
+		{"RDB$INDICES", "NO_SUCH_FIELD"}:  false,
+	} {
+		if cached, found := probes.(*sync.Map).Load(key); !found || cached != exp {
+			t.Errorf("%v: expected cached <%v>, got <%v>", key, exp, cached)
+		}
+	}
+
+	// The cache does not keep a closed *sql.DB alive.
+	db.Close()
+	db, probes = nil, nil
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		runtime.GC()
+		if _, found = hasFieldCache.Load(wp); !found {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the cache entry to be dropped once the *sql.DB was collected")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if wp.Value() != nil {
+		t.Error("Expected the *sql.DB to be collected")
+	}
+}
@@ -17,6 +17,15 @@ const (
 	sql_int64     = 580
 	sql_null      = 32766
 
+	sql_boolean         = 32764
+	sql_dec16           = 32760
+	sql_dec34           = 32762
+	sql_int128          = 32752
+	sql_timestamp_tz    = 32754
+	sql_time_tz         = 32756
+	sql_timestamp_tz_ex = 32748
+	sql_time_tz_ex      = 32750
+
 	blr_text         = 14
 	blr_text2        = 15
 	blr_short        = 7
@@ -41,4 +50,13 @@ const (
 	blr_not_nullable = 20
 	blr_column_name  = 21
 	blr_column_name2 = 22
+
+	blr_bool            = 23
+	blr_dec64           = 24
+	blr_dec128          = 25
+	blr_int128          = 26
+	blr_sql_time_tz     = 28
+	blr_timestamp_tz    = 29
+	blr_ex_time_tz      = 30
+	blr_ex_timestamp_tz = 31
 )
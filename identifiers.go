@@ -0,0 +1,81 @@
+package fbx
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Identifier is the name of a database object exactly as it is stored in the
+// system tables. In dialect 3 a quoted identifier is case-sensitive and may
+// contain any character.
+type Identifier string
+
+var (
+	regularIdentifierPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_$]*$`)
+	upperIdentifierPattern   = regexp.MustCompile(`^[A-Z][A-Z0-9_$]*$`)
+)
+
+// reservedWords are the Firebird reserved words, which must be quoted to be
+// used as identifiers.
+var reservedWords = map[string]bool{}
+
+func init() {
+	for _, word := range strings.Fields(`
+		ADD ADMIN ALL ALTER AND ANY AS AT AVG BEGIN BETWEEN BIGINT BINARY BIT_LENGTH BLOB BOOLEAN BOTH BY
+		CASE CAST CHAR CHAR_LENGTH CHARACTER CHARACTER_LENGTH CHECK CLOSE COLLATE COLUMN COMMENT COMMIT
+		CONNECT CONSTRAINT CORR COUNT COVAR_POP COVAR_SAMP CREATE CROSS CURRENT CURRENT_CONNECTION
+		CURRENT_DATE CURRENT_ROLE CURRENT_TIME CURRENT_TIMESTAMP CURRENT_TRANSACTION CURRENT_USER CURSOR
+		DATE DAY DEC DECFLOAT DECIMAL DECLARE DEFAULT DELETE DELETING DETERMINISTIC DISCONNECT DISTINCT
+		DOUBLE DROP ELSE END ESCAPE EXECUTE EXISTS EXTERNAL EXTRACT FALSE FETCH FILTER FLOAT FOR FOREIGN
+		FROM FULL FUNCTION GDSCODE GLOBAL GRANT GROUP HAVING HOUR IN INDEX INNER INSENSITIVE INSERT INSERTING
+		INT INT128 INTEGER INTO IS JOIN LEADING LEFT LIKE LOCAL LOCALTIME LOCALTIMESTAMP LONG LOWER MAX
+		MERGE MIN MINUTE MONTH NATIONAL NATURAL NCHAR NO NOT NULL NUMERIC OCTET_LENGTH OF OFFSET ON ONLY
+		OPEN OR ORDER OUTER OVER PARAMETER PLAN POSITION POST_EVENT PRECISION PRIMARY PROCEDURE PUBLICATION
+		RDB$DB_KEY RDB$ERROR RDB$GET_CONTEXT RDB$GET_TRANSACTION_CN RDB$RECORD_VERSION RDB$ROLE_IN_USE
+		RDB$SET_CONTEXT RDB$SYSTEM_PRIVILEGE REAL RECORD_VERSION RECREATE RECURSIVE REFERENCES REGR_AVGX
+		REGR_AVGY REGR_COUNT REGR_INTERCEPT REGR_R2 REGR_SLOPE REGR_SXX REGR_SXY REGR_SYY RELEASE RESETTING
+		RETURN RETURNING_VALUES RETURNS REVOKE RIGHT ROLLBACK ROW ROW_COUNT ROWS SAVEPOINT SCROLL SECOND
+		SELECT SENSITIVE SET SIMILAR SMALLINT SOME SQLCODE SQLSTATE START STDDEV_POP STDDEV_SAMP SUM TABLE
+		THEN TIME TIMESTAMP TIMEZONE_HOUR TIMEZONE_MINUTE TO TRAILING TRIGGER TRIM TRUE UNBOUNDED UNION
+		UNIQUE UNKNOWN UPDATE UPDATING UPPER USER USING VALUE VALUES VAR_POP VAR_SAMP VARBINARY VARCHAR
+		VARIABLE VARYING VIEW WHEN WHERE WHILE WINDOW WITH WITHOUT YEAR`) {
+		reservedWords[word] = true
+	}
+}
+
+// ParseIdentifier converts an identifier as written in SQL into the stored
+// name: regular identifiers are folded to upper case and delimited
+// identifiers have their quotes removed.
+func ParseIdentifier(s string) (id Identifier, err error) {
+	s = strings.TrimSpace(s)
+	if regularIdentifierPattern.MatchString(s) {
+		return Identifier(strings.ToUpper(s)), nil
+	}
+	if n := len(s); n > 2 && s[0] == '"' && s[n-1] == '"' {
+		inner := s[1 : n-1]
+		if strings.Count(inner, `"`) == 2*strings.Count(inner, `""`) {
+			return Identifier(strings.Replace(inner, `""`, `"`, -1)), nil
+		}
+	}
+	return "", fmt.Errorf("fbx: invalid identifier %q", s)
+}
+
+// Quoted returns the identifier as a dialect 3 delimited identifier.
+func (id Identifier) Quoted() string {
+	return `"` + strings.Replace(string(id), `"`, `""`, -1) + `"`
+}
+
+func (id Identifier) String() string {
+	return string(id)
+}
+
+// QuoteIdentifier returns the stored name as it must be written in SQL:
+// upper-case regular identifiers that are not reserved words are left bare,
+// so they also work in dialect 1, and any other name is delimited.
+func QuoteIdentifier(name string) string {
+	if upperIdentifierPattern.MatchString(name) && !reservedWords[name] {
+		return name
+	}
+	return Identifier(name).Quoted()
+}
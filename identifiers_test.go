@@ -0,0 +1,54 @@
+package fbx
+
+import (
+	"testing"
+)
+
+func TestQuoteIdentifier(t *testing.T) {
+	tests := []struct{ name, exp string }{
+		{"TEST_SEQ", "TEST_SEQ"},
+		{"RDB$PAGES", "RDB$PAGES"},
+		{"test_seq", `"test_seq"`},
+		{"ORDER", `"ORDER"`},
+		{"_X", `"_X"`},
+		{"Mixed Case", `"Mixed Case"`},
+		{`say "hi"`, `"say ""hi"""`},
+		{`X"; DROP TABLE T; --`, `"X""; DROP TABLE T; --"`},
+	}
+	for _, tt := range tests {
+		if got := QuoteIdentifier(tt.name); got != tt.exp {
+			t.Errorf("Expected <%s>, got <%s>", tt.exp, got)
+		}
+	}
+	if got := Identifier("TEST_SEQ").Quoted(); got != `"TEST_SEQ"` {
+		t.Errorf("Expected <%s>, got <%s>", `"TEST_SEQ"`, got)
+	}
+}
+
+func TestParseIdentifier(t *testing.T) {
+	tests := []struct {
+		s   string
+		exp Identifier
+		ok  bool
+	}{
+		{"test_seq", "TEST_SEQ", true},
+		{" Rdb$Pages ", "RDB$PAGES", true},
+		{`"Mixed Case"`, "Mixed Case", true},
+		{`"say ""hi"""`, `say "hi"`, true},
+		{`"bad"quote"`, "", false},
+		{`""`, "", false},
+		{"1ABC", "", false},
+		{"two words", "", false},
+	}
+	for _, tt := range tests {
+		id, err := ParseIdentifier(tt.s)
+		if (err == nil) != tt.ok || id != tt.exp {
+			t.Errorf("%q: expected <%s> %v, got <%s> %v", tt.s, tt.exp, tt.ok, id, err)
+		}
+	}
+	for _, name := range []string{"TEST", "Mixed Case", `say "hi"`} {
+		if id, err := ParseIdentifier(QuoteIdentifier(name)); err != nil || string(id) != name {
+			t.Errorf("Expected round trip of <%s>, got <%s> %v", name, id, err)
+		}
+	}
+}
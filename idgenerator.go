@@ -0,0 +1,93 @@
+package fbx
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+)
+
+// IDGenerator hands out values of a sequence from blocks reserved with
+// NextSequenceValues, so that most IDs cost no round trip. Once half of the
+// current block is used, the next block is fetched in the background,
+// outside of any transaction. Values of a block not handed out are lost
+// when the generator is discarded. IDGenerator is safe for concurrent use.
+type IDGenerator struct {
+	fetch     func(ctx context.Context) (first int64, err error)
+	blockSize int64
+
+	mu      sync.Mutex
+	next    int64
+	limit   int64
+	pending *idBlock
+}
+
+// idBlock is a block being fetched; done is closed once first or err is set.
+type idBlock struct {
+	done  chan struct{}
+	first int64
+	err   error
+}
+
+func NewIDGenerator(db *sql.DB, name string, blockSize int64) *IDGenerator {
+	if blockSize < 1 {
+		blockSize = 1
+	}
+	return &IDGenerator{
+		fetch: func(ctx context.Context) (int64, error) {
+			return NextSequenceValuesContext(ctx, db, name, blockSize)
+		},
+		blockSize: blockSize,
+	}
+}
+
+func (g *IDGenerator) Next() (id int64, err error) {
+	return g.NextContext(context.Background())
+}
+
+// NextContext returns the next ID, waiting for a block to be fetched if the
+// current one is used up. Canceling ctx abandons the wait but not the fetch,
+// whose block remains available to later calls.
+func (g *IDGenerator) NextContext(ctx context.Context) (id int64, err error) {
+	g.mu.Lock()
+	for g.next >= g.limit {
+		if g.pending == nil {
+			g.refill()
+		}
+		block := g.pending
+		// The lock is released while waiting so that other callers can
+		// give up on their own contexts.
+		g.mu.Unlock()
+		select {
+		case <-block.done:
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+		g.mu.Lock()
+		if g.pending != block {
+			// Another caller installed the block first.
+			continue
+		}
+		g.pending = nil
+		if block.err != nil {
+			g.mu.Unlock()
+			return 0, block.err
+		}
+		g.next, g.limit = block.first, block.first+g.blockSize
+	}
+	id = g.next
+	g.next++
+	if g.pending == nil && g.limit-g.next <= g.blockSize/2 {
+		g.refill()
+	}
+	g.mu.Unlock()
+	return
+}
+
+func (g *IDGenerator) refill() {
+	block := &idBlock{done: make(chan struct{})}
+	g.pending = block
+	go func() {
+		block.first, block.err = g.fetch(context.Background())
+		close(block.done)
+	}()
+}
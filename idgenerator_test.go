@@ -0,0 +1,143 @@
+package fbx
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	_ "github.com/rowland/firebirdsql"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestIDGenerator(t *testing.T) {
+	const sqlSchema = "CREATE SEQUENCE TEST_SEQ;"
+
+	db, err := sql.Open("firebirdsql_createdb", "sysdba:masterkey@localhost:3050/tmp/fbx_test_id_generator.fdb")
+	if err != nil {
+		t.Fatalf("Error creating database: %s", err)
+	}
+	defer db.Close()
+
+	err = ExecScript(db, sqlSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gen := NewIDGenerator(db, "TEST_SEQ", 10)
+	const workers, perWorker = 8, 25
+	ids := make(chan int64, workers*perWorker)
+	errs := make(chan error, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				id, err := gen.Next()
+				if err != nil {
+					errs <- err
+					return
+				}
+				ids <- id
+			}
+		}()
+	}
+	wg.Wait()
+	close(ids)
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+
+	seen := make(map[int64]bool)
+	for id := range ids {
+		if seen[id] {
+			t.Errorf("Duplicate ID <%d>", id)
+		}
+		seen[id] = true
+	}
+	if len(seen) != workers*perWorker {
+		t.Errorf("Expected <%d> IDs, got <%d>", workers*perWorker, len(seen))
+	}
+}
+
+func TestIDGeneratorCancel(t *testing.T) {
+	release := make(chan struct{})
+	var fetches int64
+	var fetchMu sync.Mutex
+	gen := &IDGenerator{
+		fetch: func(ctx context.Context) (int64, error) {
+			<-release
+			fetchMu.Lock()
+			defer fetchMu.Unlock()
+			fetches++
+			return (fetches-1)*10 + 1, nil
+		},
+		blockSize: 10,
+	}
+
+	// The first caller waits on a slow fetch started through Next.
+	first := make(chan int64)
+	go func() {
+		id, err := gen.Next()
+		if err != nil {
+			t.Error(err)
+		}
+		first <- id
+	}()
+
+	// A second caller gives up as soon as its context is canceled.
+	ctx, cancel := context.WithCancel(context.Background())
+	canceled := make(chan error)
+	go func() {
+		_, err := gen.NextContext(ctx)
+		canceled <- err
+	}()
+	cancel()
+	select {
+	case err := <-canceled:
+		if err != context.Canceled {
+			t.Errorf("Expected <%v>, got <%v>", context.Canceled, err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("NextContext did not return after cancellation")
+	}
+
+	// The abandoned fetch still serves later calls.
+	close(release)
+	if id := <-first; id != 1 {
+		t.Errorf("Expected <1>, got <%d>", id)
+	}
+	for exp := int64(2); exp <= 25; exp++ {
+		id, err := gen.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id != exp {
+			t.Errorf("Expected <%d>, got <%d>", exp, id)
+		}
+	}
+}
+
+func TestIDGeneratorError(t *testing.T) {
+	fail := errors.New("fetch failed")
+	calls := 0
+	gen := &IDGenerator{
+		fetch: func(ctx context.Context) (int64, error) {
+			calls++
+			if calls == 1 {
+				return 0, fail
+			}
+			return 100, nil
+		},
+		blockSize: 2,
+	}
+	if _, err := gen.Next(); err != fail {
+		t.Errorf("Expected <%v>, got <%v>", fail, err)
+	}
+	// A failed block is not retained; the next call fetches again.
+	if id, err := gen.Next(); err != nil || id != 100 {
+		t.Errorf("Expected <100>, got <%d> %v", id, err)
+	}
+}
@@ -1,67 +1,118 @@
 package fbx
 
 import (
+	"context"
 	"database/sql"
+	"fmt"
 	"strings"
 	"unicode"
 )
 
+// Index describes an index on a user relation. Expression is valid for
+// expression indexes, which have no Columns, and Condition for partial
+// indexes (Firebird 5+). Constraint names the type of constraint the index
+// backs (PRIMARY KEY, FOREIGN KEY or UNIQUE), if any.
 type Index struct {
 	Name       string
 	TableName  string
 	Unique     sql.NullBool
 	Descending sql.NullBool
+	Active     bool
+	Expression sql.NullString
+	Condition  sql.NullString
+	Statistics sql.NullFloat64
+	Constraint string
 	Columns    []string
 }
 
-func Indexes(db *sql.DB) (indexes []*Index, err error) {
-	const query = `
-		SELECT RDB$INDICES.RDB$RELATION_NAME, RDB$INDICES.RDB$INDEX_NAME, RDB$INDICES.RDB$UNIQUE_FLAG, RDB$INDICES.RDB$INDEX_TYPE
-		FROM RDB$INDICES
-		JOIN RDB$RELATIONS ON RDB$INDICES.RDB$RELATION_NAME = RDB$RELATIONS.RDB$RELATION_NAME
-		WHERE (RDB$RELATIONS.RDB$SYSTEM_FLAG <> 1 OR RDB$RELATIONS.RDB$SYSTEM_FLAG IS NULL);`
-	return queryIndexes(db, query)
+// The condition column is substituted because RDB$CONDITION_SOURCE only
+// exists from Firebird 5 on; the probe for it is cached per *sql.DB. Segments
+// are joined in so that all indexes load in a single query.
+const indexesQuery = `
+	SELECT RDB$INDICES.RDB$RELATION_NAME, RDB$INDICES.RDB$INDEX_NAME, RDB$INDICES.RDB$UNIQUE_FLAG, RDB$INDICES.RDB$INDEX_TYPE,
+		RDB$INDICES.RDB$INDEX_INACTIVE, RDB$INDICES.RDB$EXPRESSION_SOURCE, %s, RDB$INDICES.RDB$STATISTICS,
+		RDB$RELATION_CONSTRAINTS.RDB$CONSTRAINT_TYPE, RDB$INDEX_SEGMENTS.RDB$FIELD_NAME
+	FROM RDB$INDICES
+	JOIN RDB$RELATIONS ON RDB$INDICES.RDB$RELATION_NAME = RDB$RELATIONS.RDB$RELATION_NAME
+	LEFT JOIN RDB$RELATION_CONSTRAINTS ON RDB$INDICES.RDB$INDEX_NAME = RDB$RELATION_CONSTRAINTS.RDB$INDEX_NAME
+	LEFT JOIN RDB$INDEX_SEGMENTS ON RDB$INDICES.RDB$INDEX_NAME = RDB$INDEX_SEGMENTS.RDB$INDEX_NAME
+	WHERE (RDB$RELATIONS.RDB$SYSTEM_FLAG <> 1 OR RDB$RELATIONS.RDB$SYSTEM_FLAG IS NULL)
+	%s
+	ORDER BY RDB$INDICES.RDB$RELATION_NAME, RDB$INDICES.RDB$INDEX_NAME, RDB$INDEX_SEGMENTS.RDB$FIELD_POSITION`
+
+func Indexes(db Querier) (indexes []*Index, err error) {
+	return IndexesContext(context.Background(), db)
+}
+
+func IndexesContext(ctx context.Context, db Querier) (indexes []*Index, err error) {
+	return queryIndexes(ctx, db, "")
+}
+
+func IndexesOnTable(db Querier, tableName string) (indexes []*Index, err error) {
+	return IndexesOnTableContext(context.Background(), db, tableName)
 }
 
-func IndexesOnTable(db *sql.DB, tableName string) (indexes []*Index, err error) {
-	const query = `
-		SELECT RDB$INDICES.RDB$RELATION_NAME, RDB$INDICES.RDB$INDEX_NAME, RDB$INDICES.RDB$UNIQUE_FLAG, RDB$INDICES.RDB$INDEX_TYPE
-		FROM RDB$INDICES
-		JOIN RDB$RELATIONS ON RDB$INDICES.RDB$RELATION_NAME = RDB$RELATIONS.RDB$RELATION_NAME
-		WHERE (RDB$RELATIONS.RDB$SYSTEM_FLAG <> 1 OR RDB$RELATIONS.RDB$SYSTEM_FLAG IS NULL)
-		AND RDB$INDICES.RDB$RELATION_NAME = ?;`
-	return queryIndexes(db, query, tableName)
+func IndexesOnTableContext(ctx context.Context, db Querier, tableName string) (indexes []*Index, err error) {
+	return queryIndexes(ctx, db, "AND RDB$INDICES.RDB$RELATION_NAME = ?", tableName)
 }
 
-func queryIndexes(db *sql.DB, query string, args ...interface{}) (indexes []*Index, err error) {
-	rows, err := db.Query(query, args...)
+func queryIndexes(ctx context.Context, db Querier, where string, args ...interface{}) (indexes []*Index, err error) {
+	condition := "CAST(NULL AS BLOB SUB_TYPE TEXT)"
+	var partial bool
+	if partial, err = hasField(ctx, db, "RDB$INDICES", "RDB$CONDITION_SOURCE"); err != nil {
+		return
+	} else if partial {
+		condition = "RDB$INDICES.RDB$CONDITION_SOURCE"
+	}
+	query := fmt.Sprintf(indexesQuery, condition, where)
+
+	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return
 	}
 	defer rows.Close()
 
+	// Each row carries one segment; rows of the same index are adjacent.
+	var last *Index
 	for rows.Next() {
 		var index Index
-		var unique sql.NullInt64
+		var unique, descending, inactive sql.NullInt64
+		var constraint, column sql.NullString
 		if err = rows.Scan(
 			&index.TableName,
 			&index.Name,
 			&unique,
-			&index.Unique); err != nil {
+			&descending,
+			&inactive,
+			&index.Expression,
+			&index.Condition,
+			&index.Statistics,
+			&constraint,
+			&column); err != nil {
 			return
 		}
-		index.Unique.Bool, index.Unique.Valid = (unique.Int64 == 1), unique.Valid
 		index.Name = strings.TrimRightFunc(index.Name, unicode.IsSpace)
 		index.TableName = strings.TrimRightFunc(index.TableName, unicode.IsSpace)
-		indexes = append(indexes, &index)
-	}
-	if err = rows.Err(); err != nil {
-		return
-	}
-	for _, index := range indexes {
-		if index.Columns, err = IndexColumnNames(db, index.Name); err != nil {
-			return
+		if last != nil && last.Name == index.Name && last.TableName == index.TableName {
+			last.Columns = append(last.Columns, strings.TrimRightFunc(column.String, unicode.IsSpace))
+			continue
+		}
+		index.Unique.Bool, index.Unique.Valid = (unique.Int64 == 1), unique.Valid
+		index.Descending.Bool, index.Descending.Valid = (descending.Int64 == 1), descending.Valid
+		index.Active = inactive.Int64 == 0
+		index.Constraint = strings.TrimRightFunc(constraint.String, unicode.IsSpace)
+		if index.Expression.Valid {
+			index.Expression.String = strings.TrimSpace(index.Expression.String)
+		}
+		if index.Condition.Valid {
+			index.Condition.String = strings.TrimSpace(index.Condition.String)
+		}
+		if column.Valid {
+			index.Columns = append(index.Columns, strings.TrimRightFunc(column.String, unicode.IsSpace))
 		}
+		last = &index
+		indexes = append(indexes, last)
 	}
+	err = rows.Err()
 	return
 }
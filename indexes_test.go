@@ -3,6 +3,7 @@ package fbx
 import (
 	"database/sql"
 	_ "github.com/rowland/firebirdsql"
+	"reflect"
 	"testing"
 )
 
@@ -101,3 +102,147 @@ func TestIndexesOnTable(t *testing.T) {
 		t.Errorf("Expected Column <%s>, got <%s>", "NAME", indexes[0].Columns[1])
 	}
 }
+
+func TestIndexAttributes(t *testing.T) {
+	const sqlSchema = `
+		CREATE TABLE TEST(ID INT NOT NULL PRIMARY KEY, NAME VARCHAR(20) NOT NULL, CREATED TIMESTAMP);
+		CREATE DESCENDING INDEX TEST_CREATED ON TEST (CREATED);
+		CREATE INDEX TEST_UPPER_NAME ON TEST COMPUTED BY (UPPER(NAME));
+		CREATE INDEX TEST_NAME ON TEST (NAME);
+		ALTER INDEX TEST_NAME INACTIVE;
+		INSERT INTO TEST (ID, NAME) VALUES (1, 'a');
+		INSERT INTO TEST (ID, NAME) VALUES (2, 'b');
+		SET STATISTICS INDEX TEST_CREATED;`
+
+	db, err := sql.Open("firebirdsql_createdb", "sysdba:masterkey@localhost:3050/tmp/fbx_test_index_attributes.fdb")
+	if err != nil {
+		t.Fatalf("Error creating database: %s", err)
+	}
+	defer db.Close()
+
+	err = ExecScript(db, sqlSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	indexes, err := IndexesOnTable(db, "TEST")
+	if err != nil {
+		t.Fatal(err)
+	}
+	byName := make(map[string]*Index)
+	for _, index := range indexes {
+		byName[index.Name] = index
+	}
+	if len(byName) != 4 {
+		t.Fatalf("Expected 4 indexes, got %d", len(byName))
+	}
+	for name, index := range byName {
+		if name != "TEST_CREATED" && index.Descending.Bool {
+			t.Errorf("Expected %s to be ascending", name)
+		}
+		if name != "TEST_NAME" && !index.Active {
+			t.Errorf("Expected %s to be active", name)
+		}
+		if name != "TEST_UPPER_NAME" && index.Expression.Valid {
+			t.Errorf("Expected %s to have no expression", name)
+		}
+		if index.Condition.Valid {
+			t.Errorf("Expected %s to have no condition, got <%s>", name, index.Condition.String)
+		}
+		if name == "TEST_CREATED" || name == "TEST_UPPER_NAME" || name == "TEST_NAME" {
+			if index.Constraint != "" {
+				t.Errorf("Expected %s to back no constraint, got <%s>", name, index.Constraint)
+			}
+		} else if index.Constraint != "PRIMARY KEY" {
+			t.Errorf("Expected %s to back the primary key, got <%s>", name, index.Constraint)
+		}
+	}
+	if created := byName["TEST_CREATED"]; created == nil || !created.Descending.Bool {
+		t.Error("Expected TEST_CREATED to be descending")
+	} else if !created.Statistics.Valid || created.Statistics.Float64 <= 0 || created.Statistics.Float64 > 1 {
+		t.Errorf("Expected TEST_CREATED to have a selectivity in (0, 1], got %v", created.Statistics)
+	}
+	if name := byName["TEST_NAME"]; name == nil || name.Active {
+		t.Error("Expected TEST_NAME to be inactive")
+	}
+	if upper := byName["TEST_UPPER_NAME"]; upper == nil || upper.Expression.String != "(UPPER(NAME))" || len(upper.Columns) != 0 {
+		t.Errorf("Expected TEST_UPPER_NAME to be an expression index on (UPPER(NAME)), got %#v", upper)
+	}
+}
+
+func TestIndexesPartial(t *testing.T) {
+	const sqlSchema = `
+		CREATE TABLE TEST(ID INT NOT NULL PRIMARY KEY, NAME VARCHAR(20), DELETED BOOLEAN);
+		CREATE INDEX TEST_LIVE_NAME ON TEST (NAME) WHERE DELETED IS NOT TRUE;`
+
+	db, err := sql.Open("firebirdsql_createdb", "sysdba:masterkey@localhost:3050/tmp/fbx_test_indexes_partial.fdb")
+	if err != nil {
+		t.Fatalf("Error creating database: %s", err)
+	}
+	defer db.Close()
+
+	if err = ExecScript(db, sqlSchema); err != nil {
+		t.Skipf("Server does not support partial indexes: %s", err)
+	}
+
+	indexes, err := IndexesOnTable(db, "TEST")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var partial *Index
+	for _, index := range indexes {
+		if index.Name == "TEST_LIVE_NAME" {
+			partial = index
+		} else if index.Condition.Valid {
+			t.Errorf("Expected %s to have no condition, got <%s>", index.Name, index.Condition.String)
+		}
+	}
+	if partial == nil {
+		t.Fatal("Expected index TEST_LIVE_NAME")
+	}
+	if partial.Condition != (sql.NullString{"WHERE DELETED IS NOT TRUE", true}) {
+		t.Errorf("Expected condition <WHERE DELETED IS NOT TRUE>, got %#v", partial.Condition)
+	}
+	if !reflect.DeepEqual(partial.Columns, []string{"NAME"}) {
+		t.Errorf("Expected columns [NAME], got %v", partial.Columns)
+	}
+}
+
+func TestIndexesColumns(t *testing.T) {
+	const sqlSchema = `
+		CREATE TABLE TEST1(ID INT NOT NULL, NAME VARCHAR(20) NOT NULL);
+		ALTER TABLE TEST1 ADD CONSTRAINT PK1 PRIMARY KEY(NAME, ID);
+		CREATE INDEX TEST1_ID ON TEST1 (ID);
+		CREATE TABLE TEST2(A INT, B INT, C INT);
+		CREATE INDEX TEST2_CBA ON TEST2 (C, B, A);
+		CREATE INDEX TEST2_EXPR ON TEST2 COMPUTED BY (A + B);`
+
+	db, err := sql.Open("firebirdsql_createdb", "sysdba:masterkey@localhost:3050/tmp/fbx_test_indexes_columns.fdb")
+	if err != nil {
+		t.Fatalf("Error creating database: %s", err)
+	}
+	defer db.Close()
+
+	err = ExecScript(db, sqlSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	indexes, err := Indexes(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	columns := make(map[string][]string)
+	for _, index := range indexes {
+		columns[index.Name] = index.Columns
+	}
+	exp := map[string][]string{
+		"PK1":        {"NAME", "ID"},
+		"TEST1_ID":   {"ID"},
+		"TEST2_CBA":  {"C", "B", "A"},
+		"TEST2_EXPR": nil,
+	}
+	if !reflect.DeepEqual(exp, columns) {
+		t.Errorf("Expected %v, got %v", exp, columns)
+	}
+}
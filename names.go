@@ -1,13 +1,17 @@
 package fbx
 
 import (
-	"database/sql"
+	"context"
 	"strings"
 	"unicode"
 )
 
-func ColumnNames(db *sql.DB, tableName string) (names []string, err error) {
-	cols, err := Columns(db, tableName)
+func ColumnNames(db Querier, tableName string) (names []string, err error) {
+	return ColumnNamesContext(context.Background(), db, tableName)
+}
+
+func ColumnNamesContext(ctx context.Context, db Querier, tableName string) (names []string, err error) {
+	cols, err := ColumnsContext(ctx, db, tableName)
 	if err != nil {
 		return
 	}
@@ -17,15 +21,23 @@ func ColumnNames(db *sql.DB, tableName string) (names []string, err error) {
 	return
 }
 
-func IndexColumnNames(db *sql.DB, indexName string) (names []string, err error) {
+func IndexColumnNames(db Querier, indexName string) (names []string, err error) {
+	return IndexColumnNamesContext(context.Background(), db, indexName)
+}
+
+func IndexColumnNamesContext(ctx context.Context, db Querier, indexName string) (names []string, err error) {
 	const query = `SELECT RDB$FIELD_NAME
 		FROM RDB$INDEX_SEGMENTS 
 		WHERE RDB$INDEX_SEGMENTS.RDB$INDEX_NAME = ? 
 		ORDER BY RDB$INDEX_SEGMENTS.RDB$FIELD_POSITION`
-	return queryNames(db, query, indexName)
+	return queryNames(ctx, db, query, indexName)
 }
 
-func PrimaryKey(db *sql.DB, tableName string) (names []string, err error) {
+func PrimaryKey(db Querier, tableName string) (names []string, err error) {
+	return PrimaryKeyContext(context.Background(), db, tableName)
+}
+
+func PrimaryKeyContext(ctx context.Context, db Querier, tableName string) (names []string, err error) {
 	const query = `
 		SELECT S.RDB$FIELD_NAME
 		FROM RDB$INDICES I
@@ -33,47 +45,71 @@ func PrimaryKey(db *sql.DB, tableName string) (names []string, err error) {
 			LEFT JOIN RDB$RELATION_CONSTRAINTS C ON I.RDB$INDEX_NAME = C.RDB$INDEX_NAME
 		WHERE I.RDB$RELATION_NAME = ? AND C.RDB$CONSTRAINT_TYPE = 'PRIMARY KEY'
 		ORDER BY RDB$FIELD_POSITION;`
-	return queryNames(db, query, tableName)
+	return queryNames(ctx, db, query, tableName)
+}
+
+func ProcedureNames(db Querier) (names []string, err error) {
+	return ProcedureNamesContext(context.Background(), db)
 }
 
-func ProcedureNames(db *sql.DB) (names []string, err error) {
+func ProcedureNamesContext(ctx context.Context, db Querier) (names []string, err error) {
 	const query = "SELECT RDB$PROCEDURE_NAME FROM RDB$PROCEDURES ORDER BY RDB$PROCEDURE_NAME"
-	return queryNames(db, query)
+	return queryNames(ctx, db, query)
+}
+
+func RoleNames(db Querier) (names []string, err error) {
+	return RoleNamesContext(context.Background(), db)
 }
 
-func RoleNames(db *sql.DB) (names []string, err error) {
+func RoleNamesContext(ctx context.Context, db Querier) (names []string, err error) {
 	const query = "SELECT RDB$ROLE_NAME FROM RDB$ROLES WHERE RDB$SYSTEM_FLAG = 0 ORDER BY RDB$ROLE_NAME"
-	return queryNames(db, query)
+	return queryNames(ctx, db, query)
+}
+
+func SequenceNames(db Querier) (names []string, err error) {
+	return SequenceNamesContext(context.Background(), db)
 }
 
-func SequenceNames(db *sql.DB) (names []string, err error) {
+func SequenceNamesContext(ctx context.Context, db Querier) (names []string, err error) {
 	const query = `SELECT RDB$GENERATOR_NAME FROM RDB$GENERATORS 
 		WHERE (RDB$SYSTEM_FLAG IS NULL OR RDB$SYSTEM_FLAG <> 1) 
 		ORDER BY RDB$GENERATOR_NAME`
-	return queryNames(db, query)
+	return queryNames(ctx, db, query)
+}
+
+func TableNames(db Querier) (names []string, err error) {
+	return TableNamesContext(context.Background(), db)
 }
 
-func TableNames(db *sql.DB) (names []string, err error) {
+func TableNamesContext(ctx context.Context, db Querier) (names []string, err error) {
 	const query = `SELECT RDB$RELATION_NAME FROM RDB$RELATIONS 
 		WHERE (RDB$SYSTEM_FLAG <> 1 OR RDB$SYSTEM_FLAG IS NULL) AND RDB$VIEW_BLR IS NULL 
 		ORDER BY RDB$RELATION_NAME`
-	return queryNames(db, query)
+	return queryNames(ctx, db, query)
 }
 
-func TriggerNames(db *sql.DB) (names []string, err error) {
+func TriggerNames(db Querier) (names []string, err error) {
+	return TriggerNamesContext(context.Background(), db)
+}
+
+func TriggerNamesContext(ctx context.Context, db Querier) (names []string, err error) {
 	const query = "SELECT RDB$TRIGGER_NAME FROM RDB$TRIGGERS WHERE RDB$SYSTEM_FLAG = 0 ORDER BY RDB$TRIGGER_NAME"
-	return queryNames(db, query)
+	return queryNames(ctx, db, query)
+}
+
+func ViewNames(db Querier) (names []string, err error) {
+	return ViewNamesContext(context.Background(), db)
 }
 
-func ViewNames(db *sql.DB) (names []string, err error) {
+func ViewNamesContext(ctx context.Context, db Querier) (names []string, err error) {
 	const query = `SELECT RDB$RELATION_NAME FROM RDB$RELATIONS 
 		WHERE (RDB$SYSTEM_FLAG <> 1 OR RDB$SYSTEM_FLAG IS NULL) AND NOT RDB$VIEW_BLR IS NULL AND RDB$FLAGS = 1 
 		ORDER BY RDB$RELATION_NAME`
-	return queryNames(db, query)
+	return queryNames(ctx, db, query)
 }
 
-func queryNames(db *sql.DB, query string, args ...interface{}) (names []string, err error) {
-	rows, err := db.Query(query, args...)
+func queryNames(ctx context.Context, db Querier, query string, args ...interface{}) (names []string, err error) {
+	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return
 	}
@@ -1,6 +1,7 @@
 package fbx
 
 import (
+	"context"
 	"database/sql"
 	_ "github.com/rowland/firebirdsql"
 	"reflect"
@@ -103,11 +104,13 @@ func TestPrimaryKey(t *testing.T) {
 
 func TestProcedureNames(t *testing.T) {
 	const sqlSchema = `
+		SET TERM ^ ;
 		CREATE PROCEDURE PLUSONE(NUM1 INTEGER) RETURNS (NUM2 INTEGER) AS
 		BEGIN
 		  NUM2 = NUM1 + 1;
 		  SUSPEND;
-		END;`
+		END^
+		SET TERM ; ^`
 
 	db, err := sql.Open("firebirdsql_createdb", "sysdba:masterkey@localhost:3050/tmp/fbx_test_procedure_names.fdb")
 	if err != nil {
@@ -115,7 +118,7 @@ func TestProcedureNames(t *testing.T) {
 	}
 	defer db.Close()
 
-	_, err = db.Exec(sqlSchema)
+	err = ExecScript(db, sqlSchema)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -229,13 +232,14 @@ func TestTableNames(t *testing.T) {
 func TestTriggerNames(t *testing.T) {
 	const sqlSchema = `
 		CREATE TABLE TEST (ID INT, NAME VARCHAR(20));
-		CREATE GENERATOR TEST_SEQ;`
-	const triggerSchema = `
+		CREATE GENERATOR TEST_SEQ;
+		SET TERM ^ ;
 		CREATE TRIGGER TEST_INSERT FOR TEST ACTIVE BEFORE INSERT AS
 		BEGIN
 			IF (NEW.ID IS NULL) THEN
 				NEW.ID = CAST(GEN_ID(TEST_SEQ, 1) AS INT);
-		END`
+		END^
+		SET TERM ; ^`
 
 	db, err := sql.Open("firebirdsql_createdb", "sysdba:masterkey@localhost:3050/tmp/fbx_test_trigger_names.fdb")
 	if err != nil {
@@ -247,10 +251,6 @@ func TestTriggerNames(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	_, err = db.Exec(triggerSchema)
-	if err != nil {
-		t.Fatal(err)
-	}
 
 	triggerNames, err := TriggerNames(db)
 	if err != nil {
@@ -298,3 +298,62 @@ func TestViewNames(t *testing.T) {
 		t.Errorf("Expected <VIEW2>, got <%s>.", viewNames[1])
 	}
 }
+
+func TestTableNamesContext(t *testing.T) {
+	const sqlSchema = "CREATE TABLE TEST1 (ID INTEGER);"
+
+	db, err := sql.Open("firebirdsql_createdb", "sysdba:masterkey@localhost:3050/tmp/fbx_test_table_names_context.fdb")
+	if err != nil {
+		t.Fatalf("Error creating database: %s", err)
+	}
+	defer db.Close()
+
+	err = ExecScriptContext(context.Background(), db, sqlSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tableNames, err := TableNamesContext(context.Background(), db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tableNames) != 1 {
+		t.Fatalf("Expected %d table names, got %d", 1, len(tableNames))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err = TableNamesContext(ctx, db); err == nil {
+		t.Error("Expected error from canceled context")
+	}
+}
+
+func TestTableNamesInTransaction(t *testing.T) {
+	db, err := sql.Open("firebirdsql_createdb", "sysdba:masterkey@localhost:3050/tmp/fbx_test_table_names_tx.fdb")
+	if err != nil {
+		t.Fatalf("Error creating database: %s", err)
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+
+	err = ExecScript(tx, "CREATE TABLE TEST1 (ID INTEGER);")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tableNames, err := TableNames(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tableNames) != 1 {
+		t.Fatalf("Expected %d table names, got %d", 1, len(tableNames))
+	}
+	if tableNames[0] != "TEST1" {
+		t.Errorf("Expected <TEST1>, got <%s>.", tableNames[0])
+	}
+}
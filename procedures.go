@@ -0,0 +1,164 @@
+package fbx
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Procedure describes a stored procedure. Selectable reports whether it
+// returns rows through SUSPEND and must be called with SELECT rather than
+// EXECUTE PROCEDURE. Package names the package of a packaged procedure
+// (Firebird 3+); names are only unique within a package.
+type Procedure struct {
+	Name        string
+	Package     string
+	Inputs      []*Parameter
+	Outputs     []*Parameter
+	Selectable  bool
+	Source      sql.NullString
+	Valid       bool
+	Description sql.NullString
+}
+
+type Parameter struct {
+	Name       string
+	Domain     string
+	SqlType    string
+	SqlSubtype sql.NullInt64
+	Length     int16
+	Precision  sql.NullInt64
+	Scale      int16
+	Default    sql.NullString
+	NotNull    bool
+}
+
+var suspendPattern = regexp.MustCompile(`(?i)\bSUSPEND\b`)
+
+func Procedures(db Querier) (procedures []*Procedure, err error) {
+	return ProceduresContext(context.Background(), db)
+}
+
+type procedureKey struct {
+	pkg, name string
+}
+
+func ProceduresContext(ctx context.Context, db Querier) (procedures []*Procedure, err error) {
+	const query = `
+		SELECT %s, p.rdb$procedure_name, p.rdb$procedure_type, p.rdb$procedure_source,
+			p.rdb$valid_blr, p.rdb$description
+		FROM rdb$procedures p
+		WHERE p.rdb$system_flag <> 1 OR p.rdb$system_flag IS NULL
+		ORDER BY 1, p.rdb$procedure_name`
+
+	// Packages were introduced in Firebird 3.
+	procPackage, paramPackage := "CAST(NULL AS CHAR(63))", "CAST(NULL AS CHAR(63))"
+	var packages bool
+	if packages, err = hasField(ctx, db, "RDB$PROCEDURES", "RDB$PACKAGE_NAME"); err != nil {
+		return
+	} else if packages {
+		procPackage, paramPackage = "p.rdb$package_name", "pp.rdb$package_name"
+	}
+
+	rows, err := db.QueryContext(ctx, fmt.Sprintf(query, procPackage))
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	byKey := make(map[procedureKey]*Procedure)
+	for rows.Next() {
+		var p Procedure
+		var pkg sql.NullString
+		var procType, valid sql.NullInt64
+		if err = rows.Scan(
+			&pkg,
+			&p.Name,
+			&procType,
+			&p.Source,
+			&valid,
+			&p.Description); err != nil {
+			return
+		}
+		p.Name = strings.TrimRightFunc(p.Name, unicode.IsSpace)
+		p.Package = strings.TrimRightFunc(pkg.String, unicode.IsSpace)
+		if procType.Valid {
+			p.Selectable = procType.Int64 == 1
+		} else {
+			// Firebird before 2.1 does not record the procedure type.
+			p.Selectable = suspendPattern.MatchString(p.Source.String)
+		}
+		p.Valid = !valid.Valid || valid.Int64 == 1
+		byKey[procedureKey{p.Package, p.Name}] = &p
+		procedures = append(procedures, &p)
+	}
+	if err = rows.Err(); err != nil {
+		return
+	}
+	rows.Close()
+
+	const parametersQuery = `
+		SELECT %s, pp.rdb$procedure_name, pp.rdb$parameter_type, pp.rdb$parameter_name, pp.rdb$field_source,
+			f.rdb$field_type, f.rdb$field_sub_type, f.rdb$field_length, f.rdb$field_precision, f.rdb$field_scale,
+			COALESCE(pp.rdb$default_source, f.rdb$default_source),
+			COALESCE(pp.rdb$null_flag, f.rdb$null_flag)
+		FROM rdb$procedure_parameters pp
+		JOIN rdb$fields f ON pp.rdb$field_source = f.rdb$field_name
+		ORDER BY 1, pp.rdb$procedure_name, pp.rdb$parameter_type, pp.rdb$parameter_number`
+
+	if rows, err = db.QueryContext(ctx, fmt.Sprintf(parametersQuery, paramPackage)); err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var param Parameter
+		var pkg sql.NullString
+		var procName string
+		var paramType, sqlType int16
+		var notNull sql.NullInt64
+		if err = rows.Scan(
+			&pkg,
+			&procName,
+			&paramType,
+			&param.Name,
+			&param.Domain,
+			&sqlType,
+			&param.SqlSubtype,
+			&param.Length,
+			&param.Precision,
+			&param.Scale,
+			&param.Default,
+			&notNull); err != nil {
+			return
+		}
+		key := procedureKey{strings.TrimRightFunc(pkg.String, unicode.IsSpace), strings.TrimRightFunc(procName, unicode.IsSpace)}
+		p, ok := byKey[key]
+		if !ok {
+			continue
+		}
+		param.Name = strings.TrimRightFunc(param.Name, unicode.IsSpace)
+		param.Domain = strings.TrimRightFunc(param.Domain, unicode.IsSpace)
+		if strings.HasPrefix(param.Domain, "RDB$") {
+			param.Domain = ""
+		}
+		param.SqlType = sqlTypeFromCode(int(sqlType), int(param.SqlSubtype.Int64))
+		if param.Default.Valid {
+			// Parameter defaults may be declared with either DEFAULT or =.
+			param.Default.String = strings.Replace(param.Default.String, "DEFAULT ", "", 1)
+			param.Default.String = strings.TrimLeftFunc(param.Default.String, unicode.IsSpace)
+			param.Default.String = strings.TrimLeftFunc(strings.TrimPrefix(param.Default.String, "="), unicode.IsSpace)
+		}
+		param.NotNull = notNull.Int64 == 1
+		if paramType == 0 {
+			p.Inputs = append(p.Inputs, &param)
+		} else {
+			p.Outputs = append(p.Outputs, &param)
+		}
+	}
+	err = rows.Err()
+	return
+}
@@ -0,0 +1,138 @@
+package fbx
+
+import (
+	"database/sql"
+	_ "github.com/rowland/firebirdsql"
+	"testing"
+)
+
+func TestProcedures(t *testing.T) {
+	const sqlSchema = `
+		CREATE DOMAIN AMOUNT NUMERIC(15,2);
+		SET TERM ^ ;
+		CREATE PROCEDURE PLUSONE(NUM1 INTEGER NOT NULL, STEP INTEGER = 1) RETURNS (NUM2 INTEGER) AS
+		BEGIN
+		  NUM2 = NUM1 + STEP;
+		  SUSPEND;
+		END^
+		CREATE PROCEDURE ADD_TAX(PRICE AMOUNT) RETURNS (TOTAL AMOUNT, TAX AMOUNT) AS
+		BEGIN
+		  TAX = PRICE * 0.2;
+		  TOTAL = PRICE + TAX;
+		END^
+		SET TERM ; ^
+		COMMENT ON PROCEDURE ADD_TAX IS 'Adds tax';`
+
+	db, err := sql.Open("firebirdsql_createdb", "sysdba:masterkey@localhost:3050/tmp/fbx_test_procedures.fdb")
+	if err != nil {
+		t.Fatalf("Error creating database: %s", err)
+	}
+	defer db.Close()
+
+	err = ExecScript(db, sqlSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	procs, err := Procedures(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(procs) != 2 {
+		t.Fatalf("Expected %d procedures, got %d", 2, len(procs))
+	}
+
+	addTax := procs[0]
+	if addTax.Name != "ADD_TAX" {
+		t.Errorf("Expected <%s>, got <%s>", "ADD_TAX", addTax.Name)
+	}
+	if addTax.Selectable {
+		t.Error("Expected ADD_TAX to be executable")
+	}
+	if !addTax.Valid {
+		t.Error("Expected ADD_TAX to be valid")
+	}
+	if addTax.Description.String != "Adds tax" {
+		t.Errorf("Expected Description <%s>, got <%s>", "Adds tax", addTax.Description.String)
+	}
+	if len(addTax.Inputs) != 1 || len(addTax.Outputs) != 2 {
+		t.Fatalf("Expected 1 input and 2 outputs, got %d and %d", len(addTax.Inputs), len(addTax.Outputs))
+	}
+	if p := addTax.Inputs[0]; p.Name != "PRICE" || p.Domain != "AMOUNT" || p.SqlType != "NUMERIC" || p.Scale != -2 {
+		t.Errorf("Unexpected input %#v", p)
+	}
+	if addTax.Outputs[0].Name != "TOTAL" || addTax.Outputs[1].Name != "TAX" {
+		t.Errorf("Expected outputs TOTAL, TAX, got %s, %s", addTax.Outputs[0].Name, addTax.Outputs[1].Name)
+	}
+
+	plusOne := procs[1]
+	if !plusOne.Selectable {
+		t.Error("Expected PLUSONE to be selectable")
+	}
+	if len(plusOne.Inputs) != 2 || len(plusOne.Outputs) != 1 {
+		t.Fatalf("Expected 2 inputs and 1 output, got %d and %d", len(plusOne.Inputs), len(plusOne.Outputs))
+	}
+	if p := plusOne.Inputs[0]; p.Name != "NUM1" || p.SqlType != "INTEGER" || !p.NotNull || p.Domain != "" {
+		t.Errorf("Unexpected input %#v", p)
+	}
+	if p := plusOne.Inputs[1]; p.NotNull || p.Default.String != "1" {
+		t.Errorf("Expected STEP to be nullable with default <1>, got %#v", p)
+	}
+	if plusOne.Source.String == "" {
+		t.Error("Expected source")
+	}
+}
+
+func TestProceduresPackaged(t *testing.T) {
+	const sqlSchema = `
+		SET TERM ^ ;
+		CREATE PROCEDURE PLUSONE(NUM1 INTEGER) RETURNS (NUM2 INTEGER) AS
+		BEGIN
+		  NUM2 = NUM1 + 1;
+		  SUSPEND;
+		END^
+		CREATE PACKAGE MATH AS
+		BEGIN
+		  PROCEDURE PLUSONE(A INTEGER, B INTEGER) RETURNS (C INTEGER);
+		END^
+		CREATE PACKAGE BODY MATH AS
+		BEGIN
+		  PROCEDURE PLUSONE(A INTEGER, B INTEGER) RETURNS (C INTEGER) AS
+		  BEGIN
+		    C = A + B + 1;
+		  END
+		END^
+		SET TERM ; ^`
+
+	db, err := sql.Open("firebirdsql_createdb", "sysdba:masterkey@localhost:3050/tmp/fbx_test_procedures_packaged.fdb")
+	if err != nil {
+		t.Fatalf("Error creating database: %s", err)
+	}
+	defer db.Close()
+
+	if err = ExecScript(db, sqlSchema); err != nil {
+		t.Skipf("Server does not support packages: %s", err)
+	}
+
+	procs, err := Procedures(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(procs) != 2 {
+		t.Fatalf("Expected %d procedures, got %d", 2, len(procs))
+	}
+
+	standalone, packaged := procs[0], procs[1]
+	if standalone.Name != "PLUSONE" || standalone.Package != "" {
+		t.Errorf("Expected standalone PLUSONE, got <%s.%s>", standalone.Package, standalone.Name)
+	}
+	if len(standalone.Inputs) != 1 || len(standalone.Outputs) != 1 || standalone.Inputs[0].Name != "NUM1" {
+		t.Errorf("Expected standalone PLUSONE to keep its own parameters, got %d inputs and %d outputs", len(standalone.Inputs), len(standalone.Outputs))
+	}
+	if packaged.Name != "PLUSONE" || packaged.Package != "MATH" {
+		t.Errorf("Expected MATH.PLUSONE, got <%s.%s>", packaged.Package, packaged.Name)
+	}
+	if len(packaged.Inputs) != 2 || len(packaged.Outputs) != 1 || packaged.Outputs[0].Name != "C" {
+		t.Errorf("Expected MATH.PLUSONE to have 2 inputs and output C, got %d inputs and %d outputs", len(packaged.Inputs), len(packaged.Outputs))
+	}
+}
@@ -0,0 +1,25 @@
+package fbx
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Querier is the subset of *sql.DB, *sql.Tx and *sql.Conn used by this
+// package, so metadata can be read within a transaction or on a pinned
+// connection.
+type Querier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+var (
+	_ Querier = (*sql.DB)(nil)
+	_ Querier = (*sql.Tx)(nil)
+	_ Querier = (*sql.Conn)(nil)
+)
+
+type txBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
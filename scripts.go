@@ -1,21 +1,287 @@
 package fbx
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
 	"strings"
 )
 
-func ExecScript(db *sql.DB, script string) (err error) {
-	// TODO: handle "set term"
-	stmts := strings.Split(script, ";")
-	for _, stmt := range stmts {
-		if strings.TrimSpace(stmt) == "" {
-			continue
+var (
+	commitPattern   = regexp.MustCompile(`(?is)^COMMIT(\s+WORK)?(\s+RETAIN(\s+SNAPSHOT)?)?$`)
+	rollbackPattern = regexp.MustCompile(`(?is)^ROLLBACK(\s+WORK)?(\s+RETAIN(\s+SNAPSHOT)?)?$`)
+	inputPattern    = regexp.MustCompile(`(?is)^INPUT\s+(.+)$`)
+)
+
+// ScriptError reports the failure of a statement within a script.
+// Index is the 1-based position of the statement within the script;
+// Line and Column locate its text. File names the script file, if any,
+// including files pulled in by INPUT.
+type ScriptError struct {
+	File   string
+	Index  int
+	Line   int
+	Column int
+	Text   string
+	Err    error
+}
+
+func (e *ScriptError) Error() string {
+	if e.File != "" {
+		return fmt.Sprintf("fbx: %s: statement %d at line %d, column %d: %v", e.File, e.Index, e.Line, e.Column, e.Err)
+	}
+	return fmt.Sprintf("fbx: statement %d at line %d, column %d: %v", e.Index, e.Line, e.Column, e.Err)
+}
+
+func (e *ScriptError) Unwrap() error {
+	return e.Err
+}
+
+// ScriptErrors collects the failures of a script run with ContinueOnError.
+type ScriptErrors []*ScriptError
+
+func (e ScriptErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+	return fmt.Sprintf("%v (and %d more errors)", e[0], len(e)-1)
+}
+
+func (e ScriptErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+type ScriptOption func(*scriptOptions)
+
+type scriptOptions struct {
+	transaction     bool
+	continueOnError bool
+	directives      DirectiveHandler
+}
+
+// InTransaction runs the script in a single transaction that is committed
+// when the script succeeds and rolled back when it fails. COMMIT and
+// ROLLBACK statements in the script end the current transaction and start
+// a new one. Without it, scripts run on a *sql.Tx simply join that
+// transaction.
+func InTransaction() ScriptOption {
+	return func(o *scriptOptions) {
+		o.transaction = true
+	}
+}
+
+// ContinueOnError keeps executing after a failed statement and returns the
+// failures together as ScriptErrors.
+func ContinueOnError() ScriptOption {
+	return func(o *scriptOptions) {
+		o.continueOnError = true
+	}
+}
+
+// WithDirectiveHandler passes isql directives in the script to h. Without a
+// handler, directives are skipped rather than sent to the server.
+func WithDirectiveHandler(h DirectiveHandler) ScriptOption {
+	return func(o *scriptOptions) {
+		o.directives = h
+	}
+}
+
+func ExecScript(db Querier, script string, opts ...ScriptOption) (err error) {
+	return ExecScriptContext(context.Background(), db, script, opts...)
+}
+
+func ExecScriptContext(ctx context.Context, db Querier, script string, opts ...ScriptOption) (err error) {
+	return execScript(ctx, db, NewStatementScanner(strings.NewReader(script)), "", opts)
+}
+
+// ExecScriptReader executes the statements of a script as they are read from r.
+// Files named by INPUT are resolved relative to the working directory.
+func ExecScriptReader(db Querier, r io.Reader, opts ...ScriptOption) (err error) {
+	return ExecScriptReaderContext(context.Background(), db, r, opts...)
+}
+
+func ExecScriptReaderContext(ctx context.Context, db Querier, r io.Reader, opts ...ScriptOption) (err error) {
+	return execScript(ctx, db, NewStatementScanner(r), "", opts)
+}
+
+// ExecScriptFile executes the script in the named file.
+// Files named by INPUT are resolved relative to the including file.
+func ExecScriptFile(db Querier, path string, opts ...ScriptOption) (err error) {
+	return ExecScriptFileContext(context.Background(), db, path, opts...)
+}
+
+func ExecScriptFileContext(ctx context.Context, db Querier, path string, opts ...ScriptOption) (err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	return execScript(ctx, db, NewStatementScanner(f), path, opts)
+}
+
+type scriptRunner struct {
+	ctx  context.Context
+	db   Querier
+	tx   *sql.Tx
+	opts scriptOptions
+	errs ScriptErrors
+	open map[string]bool // files being run, to detect INPUT cycles
+	done bool            // set by EXIT or QUIT
+}
+
+func execScript(ctx context.Context, db Querier, s *StatementScanner, file string, opts []ScriptOption) (err error) {
+	r := &scriptRunner{ctx: ctx, db: db, open: make(map[string]bool)}
+	for _, opt := range opts {
+		opt(&r.opts)
+	}
+	if file != "" {
+		if abs, absErr := filepath.Abs(file); absErr == nil {
+			r.open[abs] = true
+		}
+	}
+	if err = r.begin(); err != nil {
+		return
+	}
+	if err = r.run(s, file); err != nil {
+		r.rollback()
+		return
+	}
+	if len(r.errs) > 0 {
+		r.rollback()
+		return r.errs
+	}
+	return r.commit()
+}
+
+func (r *scriptRunner) run(s *StatementScanner, file string) (err error) {
+	for i := 1; s.Scan(); i++ {
+		if err = r.ctx.Err(); err != nil {
+			return
+		}
+		stmt := s.Statement()
+		if d, ok := ParseDirective(stmt); ok {
+			if r.opts.directives != nil {
+				err = r.opts.directives(d)
+			}
+			switch d.Name {
+			case "EXIT":
+				r.done = true
+			case "QUIT":
+				// Like isql, QUIT discards the work of the current transaction.
+				r.rollback()
+				r.done = true
+			}
+		} else if m := inputPattern.FindStringSubmatch(stmt.Text); m != nil {
+			err = r.input(s, file, m[1])
+		} else {
+			err = r.exec(stmt.Text)
 		}
-		_, err = db.Exec(stmt)
 		if err != nil {
+			scriptErr, nested := err.(*ScriptError)
+			if !nested {
+				scriptErr = &ScriptError{File: file, Index: i, Line: stmt.Line, Column: stmt.Column, Text: stmt.Text, Err: err}
+			}
+			if !r.opts.continueOnError {
+				return scriptErr
+			}
+			r.errs = append(r.errs, scriptErr)
+		}
+		if r.done {
 			return
 		}
 	}
+	return s.Err()
+}
+
+// input runs the script named by an INPUT statement, which shares the
+// terminator in effect with the including script.
+func (r *scriptRunner) input(s *StatementScanner, file, name string) (err error) {
+	name = unquoteInputName(strings.TrimSpace(name))
+	if file != "" && !filepath.IsAbs(name) {
+		name = filepath.Join(filepath.Dir(file), name)
+	}
+	abs, err := filepath.Abs(name)
+	if err != nil {
+		return
+	}
+	if r.open[abs] {
+		return fmt.Errorf("fbx: INPUT cycle: %s is already being run", name)
+	}
+	r.open[abs] = true
+	defer delete(r.open, abs)
+	f, err := os.Open(name)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	included := NewStatementScanner(f)
+	included.term = s.term
+	err = r.run(included, name)
+	s.term = included.term
+	return
+}
+
+func unquoteInputName(name string) string {
+	if n := len(name); n >= 2 && (name[0] == '\'' || name[0] == '"') && name[n-1] == name[0] {
+		q := name[:1]
+		return strings.Replace(name[1:n-1], q+q, q, -1)
+	}
+	return name
+}
+
+func (r *scriptRunner) exec(text string) (err error) {
+	switch {
+	case commitPattern.MatchString(text):
+		err = r.commit()
+		if beginErr := r.begin(); err == nil {
+			err = beginErr
+		}
+	case rollbackPattern.MatchString(text):
+		err = r.rollback()
+		if beginErr := r.begin(); err == nil {
+			err = beginErr
+		}
+	case r.tx != nil:
+		_, err = r.tx.ExecContext(r.ctx, text)
+	default:
+		_, err = r.db.ExecContext(r.ctx, text)
+	}
+	return
+}
+
+func (r *scriptRunner) begin() (err error) {
+	if !r.opts.transaction {
+		return
+	}
+	b, ok := r.db.(txBeginner)
+	if !ok {
+		return errors.New("fbx: InTransaction requires a *sql.DB or *sql.Conn")
+	}
+	r.tx, err = b.BeginTx(r.ctx, nil)
+	return
+}
+
+func (r *scriptRunner) commit() (err error) {
+	if r.tx != nil {
+		err = r.tx.Commit()
+		r.tx = nil
+	}
+	return
+}
+
+func (r *scriptRunner) rollback() (err error) {
+	if r.tx != nil {
+		err = r.tx.Rollback()
+		r.tx = nil
+	}
 	return
 }
@@ -0,0 +1,273 @@
+package fbx
+
+import (
+	"database/sql"
+	"errors"
+	_ "github.com/rowland/firebirdsql"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestExecScriptError(t *testing.T) {
+	const sqlSchema = `
+		CREATE TABLE TEST (ID INT);
+		CREATE ROLE READER;
+
+		CREATE TABLE TEST (ID INT);
+		CREATE ROLE WRITER;`
+
+	db, err := sql.Open("firebirdsql_createdb", "sysdba:masterkey@localhost:3050/tmp/fbx_test_exec_script_error.fdb")
+	if err != nil {
+		t.Fatalf("Error creating database: %s", err)
+	}
+	defer db.Close()
+
+	err = ExecScript(db, sqlSchema)
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+	var scriptErr *ScriptError
+	if !errors.As(err, &scriptErr) {
+		t.Fatalf("Expected *ScriptError, got %T", err)
+	}
+	if scriptErr.Index != 3 {
+		t.Errorf("Expected Index <%d>, got <%d>", 3, scriptErr.Index)
+	}
+	if scriptErr.Line != 5 || scriptErr.Column != 3 {
+		t.Errorf("Expected line <%d>, column <%d>, got <%d>, <%d>", 5, 3, scriptErr.Line, scriptErr.Column)
+	}
+	if scriptErr.Text != "CREATE TABLE TEST (ID INT)" {
+		t.Errorf("Expected Text <%s>, got <%s>", "CREATE TABLE TEST (ID INT)", scriptErr.Text)
+	}
+	if scriptErr.Err == nil {
+		t.Error("Expected wrapped driver error")
+	}
+
+	roleNames, err := RoleNames(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(roleNames) != 1 {
+		t.Errorf("Expected %d role names, got %d", 1, len(roleNames))
+	}
+}
+
+func TestExecScriptInTransaction(t *testing.T) {
+	const sqlSchema = `
+		CREATE ROLE READER;
+		COMMIT;
+		CREATE ROLE WRITER;
+		CREATE ROLE READER;`
+
+	db, err := sql.Open("firebirdsql_createdb", "sysdba:masterkey@localhost:3050/tmp/fbx_test_exec_script_in_transaction.fdb")
+	if err != nil {
+		t.Fatalf("Error creating database: %s", err)
+	}
+	defer db.Close()
+
+	err = ExecScript(db, sqlSchema, InTransaction())
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+
+	roleNames, err := RoleNames(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(roleNames) != 1 {
+		t.Fatalf("Expected %d role names, got %d", 1, len(roleNames))
+	}
+	if roleNames[0] != "READER" {
+		t.Errorf("Expected <%s>, got <%s>", "READER", roleNames[0])
+	}
+}
+
+func TestExecScriptContinueOnError(t *testing.T) {
+	const sqlSchema = `
+		CREATE ROLE READER;
+		CREATE ROLE READER;
+		CREATE ROLE WRITER;
+		CREATE ROLE WRITER;`
+
+	db, err := sql.Open("firebirdsql_createdb", "sysdba:masterkey@localhost:3050/tmp/fbx_test_exec_script_continue_on_error.fdb")
+	if err != nil {
+		t.Fatalf("Error creating database: %s", err)
+	}
+	defer db.Close()
+
+	err = ExecScript(db, sqlSchema, ContinueOnError())
+	errs, ok := err.(ScriptErrors)
+	if !ok {
+		t.Fatalf("Expected ScriptErrors, got %T", err)
+	}
+	if len(errs) != 2 {
+		t.Fatalf("Expected %d errors, got %d", 2, len(errs))
+	}
+	if errs[0].Index != 2 || errs[1].Index != 4 {
+		t.Errorf("Expected failures in statements 2 and 4, got %d and %d", errs[0].Index, errs[1].Index)
+	}
+
+	roleNames, err := RoleNames(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(roleNames) != 2 {
+		t.Errorf("Expected %d role names, got %d", 2, len(roleNames))
+	}
+}
+
+func TestExecScriptFile(t *testing.T) {
+	const sqlMain = `
+		CREATE TABLE TEST1 (ID INTEGER);
+		SET TERM ^ ;
+		INPUT 'procs.sql'^
+		CREATE TABLE TEST2 (ID INTEGER);`
+	const sqlProcs = `
+		CREATE PROCEDURE PLUSONE(NUM1 INTEGER) RETURNS (NUM2 INTEGER) AS
+		BEGIN
+		  NUM2 = NUM1 + 1;
+		  SUSPEND;
+		END^
+		SET TERM ; ^`
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "main.sql"), []byte(sqlMain), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "procs.sql"), []byte(sqlProcs), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := sql.Open("firebirdsql_createdb", "sysdba:masterkey@localhost:3050/tmp/fbx_test_exec_script_file.fdb")
+	if err != nil {
+		t.Fatalf("Error creating database: %s", err)
+	}
+	defer db.Close()
+
+	// procs.sql switches the terminator back for the remainder of main.sql.
+	err = ExecScriptFile(db, filepath.Join(dir, "main.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tableNames, err := TableNames(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tableNames) != 2 {
+		t.Errorf("Expected %d table names, got %d", 2, len(tableNames))
+	}
+
+	procNames, err := ProcedureNames(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(procNames) != 1 || procNames[0] != "PLUSONE" {
+		t.Errorf("Expected [PLUSONE], got %v", procNames)
+	}
+}
+
+func TestExecScriptFileInputCycle(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "a.sql"), []byte("INPUT 'b.sql';"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "b.sql"), []byte("INPUT a.sql;"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The scripts never reach the server, so no database is needed.
+	err := ExecScriptFile(nil, filepath.Join(dir, "a.sql"))
+	var scriptErr *ScriptError
+	if !errors.As(err, &scriptErr) {
+		t.Fatalf("Expected *ScriptError, got %v", err)
+	}
+	if scriptErr.File != filepath.Join(dir, "b.sql") || scriptErr.Text != "INPUT a.sql" {
+		t.Errorf("Expected cycle reported at INPUT a.sql in b.sql, got %q in %s", scriptErr.Text, scriptErr.File)
+	}
+}
+
+func TestExecScriptExit(t *testing.T) {
+	// Statements after EXIT or QUIT never reach the server, so no database
+	// is needed.
+	for _, script := range []string{
+		"SET NAMES UTF8; EXIT; CREATE TABLE TEST (ID INTEGER);",
+		"QUIT; CREATE TABLE TEST (ID INTEGER);",
+	} {
+		var names []string
+		err := ExecScript(nil, script, WithDirectiveHandler(func(d Directive) error {
+			names = append(names, d.Name)
+			return nil
+		}))
+		if err != nil {
+			t.Errorf("%q: %s", script, err)
+		}
+		if len(names) == 0 || (names[len(names)-1] != "EXIT" && names[len(names)-1] != "QUIT") {
+			t.Errorf("%q: expected handler to see EXIT or QUIT, got %v", script, names)
+		}
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "main.sql"), []byte("INPUT 'exit.sql'; CREATE TABLE TEST (ID INTEGER);"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "exit.sql"), []byte("EXIT;"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ExecScriptFile(nil, filepath.Join(dir, "main.sql")); err != nil {
+		t.Errorf("Expected EXIT in an INPUT file to end the script, got %s", err)
+	}
+}
+
+func TestUnquoteInputName(t *testing.T) {
+	tests := []struct{ name, exp string }{
+		{"schema.sql", "schema.sql"},
+		{"'schema.sql'", "schema.sql"},
+		{`"my schema.sql"`, "my schema.sql"},
+		{"'it''s.sql'", "it's.sql"},
+		{"'", "'"},
+	}
+	for _, tt := range tests {
+		if got := unquoteInputName(tt.name); got != tt.exp {
+			t.Errorf("Expected <%s>, got <%s>", tt.exp, got)
+		}
+	}
+}
+
+func TestExecScriptDirectives(t *testing.T) {
+	const sqlSchema = `
+		SET SQL DIALECT 3;
+		SET NAMES UTF8;
+		CREATE DATABASE 'localhost:/tmp/fbx_test_other.fdb' DEFAULT CHARACTER SET UTF8;
+		SET AUTODDL ON;
+		CREATE TABLE TEST (ID INTEGER);`
+
+	db, err := sql.Open("firebirdsql_createdb", "sysdba:masterkey@localhost:3050/tmp/fbx_test_exec_script_directives.fdb")
+	if err != nil {
+		t.Fatalf("Error creating database: %s", err)
+	}
+	defer db.Close()
+
+	var names []string
+	err = ExecScript(db, sqlSchema, WithDirectiveHandler(func(d Directive) error {
+		names = append(names, d.Name)
+		return nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := []string{"SET SQL DIALECT", "SET NAMES", "CREATE DATABASE", "SET AUTODDL"}
+	if !reflect.DeepEqual(exp, names) {
+		t.Errorf("Expected %v, got %v", exp, names)
+	}
+
+	tableNames, err := TableNames(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tableNames) != 1 {
+		t.Errorf("Expected %d table names, got %d", 1, len(tableNames))
+	}
+}
@@ -1,12 +1,143 @@
 package fbx
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"strings"
+	"unicode"
 )
 
-func NextSequenceValue(db *sql.DB, name string) (value int64, err error) {
-	query := fmt.Sprintf("SELECT NEXT VALUE FOR %s FROM RDB$DATABASE", name)
-	err = db.QueryRow(query).Scan(&value)
+// Sequence describes a user sequence (generator). InitialValue and
+// Increment are only recorded by Firebird 3 and later.
+type Sequence struct {
+	Name         string
+	Value        int64
+	InitialValue sql.NullInt64
+	Increment    sql.NullInt64
+}
+
+func Sequences(db Querier) (sequences []*Sequence, err error) {
+	return SequencesContext(context.Background(), db)
+}
+
+func SequencesContext(ctx context.Context, db Querier) (sequences []*Sequence, err error) {
+	const query = `SELECT RDB$GENERATOR_NAME, %s FROM RDB$GENERATORS
+		WHERE (RDB$SYSTEM_FLAG IS NULL OR RDB$SYSTEM_FLAG <> 1)
+		ORDER BY RDB$GENERATOR_NAME`
+
+	fields := "CAST(NULL AS BIGINT), CAST(NULL AS INTEGER)"
+	var increments bool
+	if increments, err = hasField(ctx, db, "RDB$GENERATORS", "RDB$GENERATOR_INCREMENT"); err != nil {
+		return
+	} else if increments {
+		fields = "RDB$INITIAL_VALUE, RDB$GENERATOR_INCREMENT"
+	}
+
+	rows, err := db.QueryContext(ctx, fmt.Sprintf(query, fields))
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var seq Sequence
+		if err = rows.Scan(&seq.Name, &seq.InitialValue, &seq.Increment); err != nil {
+			return
+		}
+		seq.Name = strings.TrimRightFunc(seq.Name, unicode.IsSpace)
+		sequences = append(sequences, &seq)
+	}
+	if err = rows.Err(); err != nil {
+		return
+	}
+	rows.Close()
+	if len(sequences) == 0 {
+		return
+	}
+
+	// Current values are read for all sequences in a single statement.
+	exprs := make([]string, len(sequences))
+	dest := make([]interface{}, len(sequences))
+	for i, seq := range sequences {
+		exprs[i] = fmt.Sprintf("GEN_ID(%s, 0)", QuoteIdentifier(seq.Name))
+		dest[i] = &seq.Value
+	}
+	values := fmt.Sprintf("SELECT %s FROM RDB$DATABASE", strings.Join(exprs, ", "))
+	err = db.QueryRowContext(ctx, values).Scan(dest...)
+	return
+}
+
+// NextSequenceValue returns the next value of a sequence. Like the other
+// sequence helpers, it takes the name exactly as stored, as returned by
+// Sequences and SequenceNames; use ParseIdentifier for a name written in SQL.
+func NextSequenceValue(db Querier, name string) (value int64, err error) {
+	return NextSequenceValueContext(context.Background(), db, name)
+}
+
+func NextSequenceValueContext(ctx context.Context, db Querier, name string) (value int64, err error) {
+	query := fmt.Sprintf("SELECT NEXT VALUE FOR %s FROM RDB$DATABASE", QuoteIdentifier(name))
+	err = db.QueryRowContext(ctx, query).Scan(&value)
+	return
+}
+
+// NextSequenceValues reserves a block of n consecutive values in one round
+// trip and returns the first of them. The block is taken with GEN_ID, so the
+// increment declared on the sequence does not apply.
+func NextSequenceValues(db Querier, name string, n int64) (first int64, err error) {
+	return NextSequenceValuesContext(context.Background(), db, name, n)
+}
+
+func NextSequenceValuesContext(ctx context.Context, db Querier, name string, n int64) (first int64, err error) {
+	if n < 1 {
+		return 0, fmt.Errorf("fbx: invalid sequence block size %d", n)
+	}
+	var last int64
+	query := fmt.Sprintf("SELECT GEN_ID(%s, %d) FROM RDB$DATABASE", QuoteIdentifier(name), n)
+	if err = db.QueryRowContext(ctx, query).Scan(&last); err != nil {
+		return
+	}
+	return last - n + 1, nil
+}
+
+// CurrentSequenceValue returns the value of a sequence without advancing it.
+func CurrentSequenceValue(db Querier, name string) (value int64, err error) {
+	return CurrentSequenceValueContext(context.Background(), db, name)
+}
+
+func CurrentSequenceValueContext(ctx context.Context, db Querier, name string) (value int64, err error) {
+	query := fmt.Sprintf("SELECT GEN_ID(%s, 0) FROM RDB$DATABASE", QuoteIdentifier(name))
+	err = db.QueryRowContext(ctx, query).Scan(&value)
+	return
+}
+
+// SetSequenceValue restarts a sequence with ALTER SEQUENCE ... RESTART WITH.
+// Before Firebird 4 the next value returned is value plus the increment;
+// from Firebird 4 on it is value itself.
+func SetSequenceValue(db Querier, name string, value int64) (err error) {
+	return SetSequenceValueContext(context.Background(), db, name, value)
+}
+
+func SetSequenceValueContext(ctx context.Context, db Querier, name string, value int64) (err error) {
+	query := fmt.Sprintf("ALTER SEQUENCE %s RESTART WITH %d", QuoteIdentifier(name), value)
+	_, err = db.ExecContext(ctx, query)
+	return
+}
+
+func CreateSequence(db Querier, name string) (err error) {
+	return CreateSequenceContext(context.Background(), db, name)
+}
+
+func CreateSequenceContext(ctx context.Context, db Querier, name string) (err error) {
+	_, err = db.ExecContext(ctx, fmt.Sprintf("CREATE SEQUENCE %s", QuoteIdentifier(name)))
+	return
+}
+
+func DropSequence(db Querier, name string) (err error) {
+	return DropSequenceContext(context.Background(), db, name)
+}
+
+func DropSequenceContext(ctx context.Context, db Querier, name string) (err error) {
+	_, err = db.ExecContext(ctx, fmt.Sprintf("DROP SEQUENCE %s", QuoteIdentifier(name)))
 	return
 }
@@ -30,3 +30,216 @@ func TestNextSequenceValue(t *testing.T) {
 		}
 	}
 }
+
+func TestNextSequenceValueInTransaction(t *testing.T) {
+	const sqlSchema = "CREATE GENERATOR TEST;"
+
+	db, err := sql.Open("firebirdsql_createdb", "sysdba:masterkey@localhost:3050/tmp/fbx_test_next_sequence_value_tx.fdb")
+	if err != nil {
+		t.Fatalf("Error creating database: %s", err)
+	}
+	defer db.Close()
+
+	err = ExecScript(db, sqlSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+
+	v, err := NextSequenceValue(tx, "TEST")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != 1 {
+		t.Errorf("Expected <%d>, got <%d>.", 1, v)
+	}
+}
+
+func TestSequences(t *testing.T) {
+	const sqlSchema = `
+		CREATE SEQUENCE TEST1_SEQ;
+		CREATE SEQUENCE TEST2_SEQ;`
+
+	db, err := sql.Open("firebirdsql_createdb", "sysdba:masterkey@localhost:3050/tmp/fbx_test_sequences.fdb")
+	if err != nil {
+		t.Fatalf("Error creating database: %s", err)
+	}
+	defer db.Close()
+
+	err = ExecScript(db, sqlSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = SetSequenceValue(db, "TEST2_SEQ", 41); err != nil {
+		t.Fatal(err)
+	}
+	if err = CreateSequence(db, "TEST3_SEQ"); err != nil {
+		t.Fatal(err)
+	}
+
+	sequences, err := Sequences(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sequences) != 3 {
+		t.Fatalf("Expected %d sequences, got %d", 3, len(sequences))
+	}
+	if sequences[0].Name != "TEST1_SEQ" || sequences[0].Value != 0 {
+		t.Errorf("Expected TEST1_SEQ at <0>, got %s at <%d>", sequences[0].Name, sequences[0].Value)
+	}
+	if sequences[2].Name != "TEST3_SEQ" {
+		t.Errorf("Expected <TEST3_SEQ>, got <%s>", sequences[2].Name)
+	}
+	if sequences[0].Increment.Valid && sequences[0].Increment.Int64 != 1 {
+		t.Errorf("Expected Increment <1>, got <%d>", sequences[0].Increment.Int64)
+	}
+
+	current, err := CurrentSequenceValue(db, "TEST2_SEQ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sequences[1].Value != current {
+		t.Errorf("Expected <%d>, got <%d>", current, sequences[1].Value)
+	}
+	next, err := NextSequenceValue(db, "TEST2_SEQ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next != current+1 {
+		t.Errorf("Expected <%d>, got <%d>", current+1, next)
+	}
+
+	if err = DropSequence(db, "TEST3_SEQ"); err != nil {
+		t.Fatal(err)
+	}
+	names, err := SequenceNames(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 2 {
+		t.Errorf("Expected %d sequence names, got %d", 2, len(names))
+	}
+}
+
+func TestSequenceQuoting(t *testing.T) {
+	db, err := sql.Open("firebirdsql_createdb", "sysdba:masterkey@localhost:3050/tmp/fbx_test_sequence_quoting.fdb")
+	if err != nil {
+		t.Fatalf("Error creating database: %s", err)
+	}
+	defer db.Close()
+
+	const name = `Mixed "Case" Seq`
+	if err = CreateSequence(db, name); err != nil {
+		t.Fatal(err)
+	}
+	v, err := NextSequenceValue(db, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != 1 {
+		t.Errorf("Expected <%d>, got <%d>.", 1, v)
+	}
+	names, err := SequenceNames(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 1 || names[0] != name {
+		t.Errorf("Expected [%s], got %v", name, names)
+	}
+}
+
+func TestSequenceLowerCaseName(t *testing.T) {
+	const sqlSchema = `
+		CREATE SEQUENCE "lower_seq";
+		CREATE SEQUENCE "MySeq";
+		CREATE SEQUENCE MYSEQ;`
+
+	db, err := sql.Open("firebirdsql_createdb", "sysdba:masterkey@localhost:3050/tmp/fbx_test_sequence_lower_case_name.fdb")
+	if err != nil {
+		t.Fatalf("Error creating database: %s", err)
+	}
+	defer db.Close()
+
+	err = ExecScript(db, sqlSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Names returned by Sequences refer back to the same sequences.
+	if err = SetSequenceValue(db, "MySeq", 100); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = NextSequenceValue(db, "lower_seq"); err != nil {
+		t.Fatal(err)
+	}
+	sequences, err := Sequences(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := make(map[string]int64)
+	for _, seq := range sequences {
+		values[seq.Name] = seq.Value
+	}
+	if len(values) != 3 || values["lower_seq"] != 1 || values["MYSEQ"] != 0 || values["MySeq"] == 0 {
+		t.Errorf("Unexpected sequence values %v", values)
+	}
+	for name, value := range values {
+		if v, err := CurrentSequenceValue(db, name); err != nil || v != value {
+			t.Errorf("%s: expected <%d>, got <%d> %v", name, value, v, err)
+		}
+	}
+
+	// A name written in SQL goes through ParseIdentifier.
+	id, err := ParseIdentifier("myseq")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, err := NextSequenceValue(db, string(id)); err != nil || v != 1 {
+		t.Errorf("Expected <1>, got <%d> %v", v, err)
+	}
+
+	if err = DropSequence(db, "lower_seq"); err != nil {
+		t.Fatal(err)
+	}
+	if names, err := SequenceNames(db); err != nil || len(names) != 2 {
+		t.Errorf("Expected 2 sequences, got %v %v", names, err)
+	}
+}
+
+func TestNextSequenceValues(t *testing.T) {
+	const sqlSchema = "CREATE SEQUENCE TEST_SEQ;"
+
+	db, err := sql.Open("firebirdsql_createdb", "sysdba:masterkey@localhost:3050/tmp/fbx_test_next_sequence_values.fdb")
+	if err != nil {
+		t.Fatalf("Error creating database: %s", err)
+	}
+	defer db.Close()
+
+	err = ExecScript(db, sqlSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := NextSequenceValues(db, "TEST_SEQ", 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != 1 {
+		t.Errorf("Expected <%d>, got <%d>", 1, first)
+	}
+	if first, err = NextSequenceValues(db, "TEST_SEQ", 5); err != nil {
+		t.Fatal(err)
+	}
+	if first != 101 {
+		t.Errorf("Expected <%d>, got <%d>", 101, first)
+	}
+	if _, err = NextSequenceValues(db, "TEST_SEQ", 0); err == nil {
+		t.Error("Expected error for empty block")
+	}
+}
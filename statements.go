@@ -0,0 +1,277 @@
+package fbx
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+const defaultTerminator = ";"
+
+var setTermPattern = regexp.MustCompile(`(?is)^SET\s+TERM\s+(\S+)$`)
+
+// Statement is a single statement of a script, positioned at the line and
+// column (both 1-based) where its text begins.
+type Statement struct {
+	Text       string
+	Line       int
+	Column     int
+	Terminator string // empty for a final statement without terminator
+}
+
+// StatementScanner splits a Firebird script into statements the way isql does,
+// honoring SET TERM, string literals, quoted identifiers and comments.
+// SET TERM itself is consumed by the scanner and never returned.
+type StatementScanner struct {
+	r    *bufio.Reader
+	term string
+	buf  bytes.Buffer
+	line int
+	col  int
+	stmt Statement
+	err  error
+}
+
+func NewStatementScanner(r io.Reader) *StatementScanner {
+	return &StatementScanner{r: bufio.NewReader(r), term: defaultTerminator, line: 1, col: 1}
+}
+
+func (s *StatementScanner) Scan() bool {
+	for s.err == nil {
+		if !s.next() {
+			return false
+		}
+		if m := setTermPattern.FindStringSubmatch(s.stmt.Text); m != nil {
+			s.term = m[1]
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+func (s *StatementScanner) Statement() Statement {
+	return s.stmt
+}
+
+func (s *StatementScanner) Err() error {
+	return s.err
+}
+
+// Terminator returns the statement terminator currently in effect.
+func (s *StatementScanner) Terminator() string {
+	return s.term
+}
+
+func SplitStatements(script string) (stmts []Statement, err error) {
+	s := NewStatementScanner(strings.NewReader(script))
+	for s.Scan() {
+		stmts = append(stmts, s.Statement())
+	}
+	err = s.Err()
+	return
+}
+
+func (s *StatementScanner) next() bool {
+	s.buf.Reset()
+	// top marks where the trailing run of unquoted, uncommented text begins;
+	// a terminator is only recognized within that run.
+	top := 0
+	for {
+		if s.buf.Len() == 0 {
+			s.stmt = Statement{Line: s.line, Column: s.col}
+		}
+		r, err := s.read()
+		if err != nil {
+			if err != io.EOF {
+				s.err = err
+				return false
+			}
+			s.stmt.Text = strings.TrimSpace(s.buf.String())
+			return s.stmt.Text != ""
+		}
+		switch {
+		case r == '\'' && s.atQuotePrefix():
+			if err = s.readQString(); err != nil {
+				s.err = err
+				return false
+			}
+			top = s.buf.Len()
+		case r == '\'' || r == '"':
+			if err = s.readQuoted(r); err != nil {
+				s.err = err
+				return false
+			}
+			top = s.buf.Len()
+		case r == '-' && s.peek() == '-':
+			if err = s.readLineComment(); err != nil {
+				s.err = err
+				return false
+			}
+			top = s.buf.Len()
+		case r == '/' && s.peek() == '*':
+			if err = s.readBlockComment(); err != nil {
+				s.err = err
+				return false
+			}
+			top = s.buf.Len()
+		default:
+			if s.buf.Len() == 0 && unicode.IsSpace(r) {
+				continue
+			}
+			s.buf.WriteRune(r)
+			if s.buf.Len()-len(s.term) >= top && bytes.HasSuffix(s.buf.Bytes(), []byte(s.term)) {
+				text := strings.TrimSpace(string(s.buf.Bytes()[:s.buf.Len()-len(s.term)]))
+				if text == "" {
+					s.buf.Reset()
+					top = 0
+					continue
+				}
+				s.stmt.Text, s.stmt.Terminator = text, s.term
+				return true
+			}
+		}
+	}
+}
+
+func (s *StatementScanner) read() (r rune, err error) {
+	if r, _, err = s.r.ReadRune(); err != nil {
+		return
+	}
+	if r == '\n' {
+		s.line++
+		s.col = 1
+	} else {
+		s.col++
+	}
+	return
+}
+
+func (s *StatementScanner) peek() rune {
+	r, _, err := s.r.ReadRune()
+	if err != nil {
+		return 0
+	}
+	s.r.UnreadRune()
+	return r
+}
+
+// atQuotePrefix reports whether the buffer ends in a standalone Q or q,
+// making the quote about to be read the start of a q'...' literal.
+func (s *StatementScanner) atQuotePrefix() bool {
+	b := s.buf.Bytes()
+	n := len(b)
+	if n == 0 || (b[n-1] != 'q' && b[n-1] != 'Q') {
+		return false
+	}
+	return n == 1 || !isIdentByte(b[n-2])
+}
+
+func (s *StatementScanner) readQuoted(quote rune) error {
+	s.buf.WriteRune(quote)
+	for {
+		r, err := s.read()
+		if err != nil {
+			return s.unterminated(err, "quoted string")
+		}
+		s.buf.WriteRune(r)
+		if r == quote {
+			if s.peek() != quote {
+				return nil
+			}
+			r, _ = s.read()
+			s.buf.WriteRune(r)
+		}
+	}
+}
+
+func (s *StatementScanner) readQString() error {
+	s.buf.WriteRune('\'')
+	open, err := s.read()
+	if err != nil {
+		return s.unterminated(err, "quoted string")
+	}
+	s.buf.WriteRune(open)
+	end := open
+	switch open {
+	case '(':
+		end = ')'
+	case '[':
+		end = ']'
+	case '{':
+		end = '}'
+	case '<':
+		end = '>'
+	}
+	for {
+		r, err := s.read()
+		if err != nil {
+			return s.unterminated(err, "quoted string")
+		}
+		s.buf.WriteRune(r)
+		if r == end && s.peek() == '\'' {
+			r, _ = s.read()
+			s.buf.WriteRune(r)
+			return nil
+		}
+	}
+}
+
+func (s *StatementScanner) readLineComment() error {
+	keep := s.buf.Len() > 0
+	if keep {
+		s.buf.WriteRune('-')
+	}
+	for {
+		r, err := s.read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if keep {
+			s.buf.WriteRune(r)
+		}
+		if r == '\n' {
+			return nil
+		}
+	}
+}
+
+func (s *StatementScanner) readBlockComment() error {
+	keep := s.buf.Len() > 0
+	if keep {
+		s.buf.WriteString("/*")
+	}
+	s.read()
+	var prev rune
+	for {
+		r, err := s.read()
+		if err != nil {
+			return s.unterminated(err, "comment")
+		}
+		if keep {
+			s.buf.WriteRune(r)
+		}
+		if r == '/' && prev == '*' {
+			return nil
+		}
+		prev = r
+	}
+}
+
+func (s *StatementScanner) unterminated(err error, what string) error {
+	if err == io.EOF {
+		return fmt.Errorf("fbx: unterminated %s", what)
+	}
+	return err
+}
+
+func isIdentByte(b byte) bool {
+	return b == '_' || b == '$' || ('0' <= b && b <= '9') || ('a' <= b && b <= 'z') || ('A' <= b && b <= 'Z')
+}
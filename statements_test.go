@@ -0,0 +1,112 @@
+package fbx
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func scanStatements(t *testing.T, script string) (stmts []string) {
+	s := NewStatementScanner(strings.NewReader(script))
+	for s.Scan() {
+		stmts = append(stmts, s.Statement().Text)
+	}
+	if s.Err() != nil {
+		t.Fatal(s.Err())
+	}
+	return
+}
+
+func TestStatementScannerSimple(t *testing.T) {
+	stmts := scanStatements(t, "CREATE TABLE TEST1 (ID INTEGER); CREATE TABLE TEST2 (ID INTEGER);")
+	exp := []string{"CREATE TABLE TEST1 (ID INTEGER)", "CREATE TABLE TEST2 (ID INTEGER)"}
+	if !reflect.DeepEqual(exp, stmts) {
+		t.Errorf("Expected %q, got %q", exp, stmts)
+	}
+}
+
+func TestStatementScannerNoFinalTerminator(t *testing.T) {
+	stmts := scanStatements(t, "CREATE ROLE READER;\n;\nCREATE ROLE WRITER\n")
+	exp := []string{"CREATE ROLE READER", "CREATE ROLE WRITER"}
+	if !reflect.DeepEqual(exp, stmts) {
+		t.Errorf("Expected %q, got %q", exp, stmts)
+	}
+}
+
+func TestStatementScannerLiterals(t *testing.T) {
+	const script = `
+		INSERT INTO T VALUES ('a;b', 'it''s; here');
+		SELECT "odd;name" FROM T;
+		SELECT q'{semi;colon's}' FROM RDB$DATABASE;
+		SELECT Q'!x;y!' FROM RDB$DATABASE;`
+	stmts := scanStatements(t, script)
+	exp := []string{
+		`INSERT INTO T VALUES ('a;b', 'it''s; here')`,
+		`SELECT "odd;name" FROM T`,
+		`SELECT q'{semi;colon's}' FROM RDB$DATABASE`,
+		`SELECT Q'!x;y!' FROM RDB$DATABASE`,
+	}
+	if !reflect.DeepEqual(exp, stmts) {
+		t.Errorf("Expected %q, got %q", exp, stmts)
+	}
+}
+
+func TestStatementScannerComments(t *testing.T) {
+	const script = `
+		-- leading comment;
+		/* block; comment */
+		CREATE TABLE T (ID INT /* inline; */, -- trailing;
+			NAME VARCHAR(10));
+		/*/ still a comment; */
+		-- final comment`
+	stmts := scanStatements(t, script)
+	exp := []string{"CREATE TABLE T (ID INT /* inline; */, -- trailing;\n\t\t\tNAME VARCHAR(10))"}
+	if !reflect.DeepEqual(exp, stmts) {
+		t.Errorf("Expected %q, got %q", exp, stmts)
+	}
+}
+
+func TestStatementScannerSetTerm(t *testing.T) {
+	const script = `
+		CREATE TABLE TEST (ID INT);
+		SET TERM ^ ;
+		CREATE TRIGGER TEST_INSERT FOR TEST ACTIVE BEFORE INSERT AS
+		BEGIN
+			NEW.ID = 1;
+		END^
+		set term ; ^
+		CREATE ROLE READER;`
+	stmts := scanStatements(t, script)
+	exp := []string{
+		"CREATE TABLE TEST (ID INT)",
+		"CREATE TRIGGER TEST_INSERT FOR TEST ACTIVE BEFORE INSERT AS\n\t\tBEGIN\n\t\t\tNEW.ID = 1;\n\t\tEND",
+		"CREATE ROLE READER",
+	}
+	if !reflect.DeepEqual(exp, stmts) {
+		t.Errorf("Expected %q, got %q", exp, stmts)
+	}
+}
+
+func TestStatementScannerUnterminated(t *testing.T) {
+	for _, script := range []string{"SELECT 'abc FROM T;", "SELECT 1 FROM T /* abc;"} {
+		if _, err := SplitStatements(script); err == nil {
+			t.Errorf("Expected error for %q", script)
+		}
+	}
+}
+
+func TestSplitStatements(t *testing.T) {
+	const script = "CREATE TABLE T (ID INT);\n  -- comment\n  SET TERM ^ ;\n\tCREATE ROLE R^\nSET TERM ; ^\nCREATE ROLE S"
+	stmts, err := SplitStatements(script)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := []Statement{
+		{Text: "CREATE TABLE T (ID INT)", Line: 1, Column: 1, Terminator: ";"},
+		{Text: "CREATE ROLE R", Line: 4, Column: 2, Terminator: "^"},
+		{Text: "CREATE ROLE S", Line: 6, Column: 1, Terminator: ""},
+	}
+	if !reflect.DeepEqual(exp, stmts) {
+		t.Errorf("Expected %+v,\n got %+v", exp, stmts)
+	}
+}
@@ -0,0 +1,135 @@
+package fbx
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"unicode"
+)
+
+// Trigger describes a trigger. Kind is DML for table and view triggers,
+// DATABASE for database-level triggers and DDL for DDL triggers
+// (Firebird 3+); TableName is empty for the latter two. Before is not
+// meaningful for database triggers. System is set for triggers created by
+// the engine, such as those implementing CHECK constraints.
+type Trigger struct {
+	Name      string
+	TableName string
+	Active    bool
+	Position  int
+	Type      int64
+	Kind      string
+	Before    bool
+	Events    []string
+	Source    sql.NullString
+	System    bool
+}
+
+const (
+	triggerTypeMask     = 3 << 13
+	triggerTypeDatabase = 1 << 13
+	triggerTypeDDL      = 2 << 13
+)
+
+var databaseTriggerEvents = []string{"CONNECT", "DISCONNECT", "TRANSACTION START", "TRANSACTION COMMIT", "TRANSACTION ROLLBACK"}
+
+var dmlTriggerEvents = []string{"", "INSERT", "UPDATE", "DELETE"}
+
+// ddlTriggerEvents maps bit positions of a DDL trigger type to events;
+// bits 13 to 15 are taken by the trigger type mask.
+var ddlTriggerEvents = map[uint]string{
+	1: "CREATE TABLE", 2: "ALTER TABLE", 3: "DROP TABLE",
+	4: "CREATE PROCEDURE", 5: "ALTER PROCEDURE", 6: "DROP PROCEDURE",
+	7: "CREATE FUNCTION", 8: "ALTER FUNCTION", 9: "DROP FUNCTION",
+	10: "CREATE TRIGGER", 11: "ALTER TRIGGER", 12: "DROP TRIGGER",
+	16: "CREATE EXCEPTION", 17: "ALTER EXCEPTION", 18: "DROP EXCEPTION",
+	19: "CREATE VIEW", 20: "ALTER VIEW", 21: "DROP VIEW",
+	22: "CREATE DOMAIN", 23: "ALTER DOMAIN", 24: "DROP DOMAIN",
+	25: "CREATE ROLE", 26: "ALTER ROLE", 27: "DROP ROLE",
+	28: "CREATE INDEX", 29: "ALTER INDEX", 30: "DROP INDEX",
+	31: "CREATE SEQUENCE", 32: "ALTER SEQUENCE", 33: "DROP SEQUENCE",
+	34: "CREATE USER", 35: "ALTER USER", 36: "DROP USER",
+	37: "CREATE COLLATION", 38: "DROP COLLATION", 39: "ALTER CHARACTER SET",
+	40: "CREATE PACKAGE", 41: "ALTER PACKAGE", 42: "DROP PACKAGE",
+	43: "CREATE PACKAGE BODY", 44: "DROP PACKAGE BODY",
+	45: "CREATE MAPPING", 46: "ALTER MAPPING", 47: "DROP MAPPING",
+}
+
+func Triggers(db Querier) (triggers []*Trigger, err error) {
+	return TriggersContext(context.Background(), db)
+}
+
+func TriggersContext(ctx context.Context, db Querier) (triggers []*Trigger, err error) {
+	const query = `
+		SELECT t.rdb$trigger_name, t.rdb$relation_name, t.rdb$trigger_inactive, t.rdb$trigger_sequence,
+			t.rdb$trigger_type, t.rdb$trigger_source, t.rdb$system_flag
+		FROM rdb$triggers t
+		ORDER BY t.rdb$relation_name, t.rdb$trigger_sequence, t.rdb$trigger_name`
+
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var trigger Trigger
+		var tableName sql.NullString
+		var inactive, position, system sql.NullInt64
+		if err = rows.Scan(
+			&trigger.Name,
+			&tableName,
+			&inactive,
+			&position,
+			&trigger.Type,
+			&trigger.Source,
+			&system); err != nil {
+			return
+		}
+		trigger.Name = strings.TrimRightFunc(trigger.Name, unicode.IsSpace)
+		trigger.TableName = strings.TrimRightFunc(tableName.String, unicode.IsSpace)
+		trigger.Active = inactive.Int64 == 0
+		trigger.Position = int(position.Int64)
+		trigger.Kind, trigger.Before, trigger.Events = decodeTriggerType(trigger.Type)
+		trigger.System = system.Int64 != 0
+		triggers = append(triggers, &trigger)
+	}
+	err = rows.Err()
+	return
+}
+
+func decodeTriggerType(t int64) (kind string, before bool, events []string) {
+	switch t & triggerTypeMask {
+	case triggerTypeDatabase:
+		if i := t &^ triggerTypeMask; 0 <= i && i < int64(len(databaseTriggerEvents)) {
+			events = append(events, databaseTriggerEvents[i])
+		}
+		return "DATABASE", false, events
+	case triggerTypeDDL:
+		before = t&1 == 0
+		all := true
+		for bit := uint(1); bit < 63; bit++ {
+			event, ok := ddlTriggerEvents[bit]
+			if !ok {
+				continue
+			}
+			if t&(1<<bit) != 0 {
+				events = append(events, event)
+			} else {
+				all = false
+			}
+		}
+		if all {
+			events = []string{"ANY DDL STATEMENT"}
+		}
+		return "DDL", before, events
+	}
+	// Up to three actions are packed in two-bit slots above the phase bit.
+	before = (t+1)&1 == 0
+	for slot := uint(1); slot <= 3; slot++ {
+		if action := ((t + 1) >> (slot*2 - 1)) & 3; action != 0 {
+			events = append(events, dmlTriggerEvents[action])
+		}
+	}
+	return "DML", before, events
+}
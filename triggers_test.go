@@ -0,0 +1,91 @@
+package fbx
+
+import (
+	"database/sql"
+	_ "github.com/rowland/firebirdsql"
+	"reflect"
+	"testing"
+)
+
+func TestDecodeTriggerType(t *testing.T) {
+	tests := []struct {
+		t      int64
+		kind   string
+		before bool
+		events []string
+	}{
+		{1, "DML", true, []string{"INSERT"}},
+		{2, "DML", false, []string{"INSERT"}},
+		{3, "DML", true, []string{"UPDATE"}},
+		{6, "DML", false, []string{"DELETE"}},
+		{17, "DML", true, []string{"INSERT", "UPDATE"}},
+		{25, "DML", true, []string{"INSERT", "DELETE"}},
+		{114, "DML", false, []string{"INSERT", "UPDATE", "DELETE"}},
+		{8192, "DATABASE", false, []string{"CONNECT"}},
+		{8196, "DATABASE", false, []string{"TRANSACTION ROLLBACK"}},
+		{16384 | 1<<1, "DDL", true, []string{"CREATE TABLE"}},
+		{16384 | 1 | 1<<3 | 1<<16, "DDL", false, []string{"DROP TABLE", "CREATE EXCEPTION"}},
+		{16384 | 1 | 0x7FFFFFFFFFFFFFFF&^(3<<13)&^1, "DDL", false, []string{"ANY DDL STATEMENT"}},
+	}
+	for _, tt := range tests {
+		kind, before, events := decodeTriggerType(tt.t)
+		if kind != tt.kind || before != tt.before || !reflect.DeepEqual(events, tt.events) {
+			t.Errorf("%d: expected %s %v %v, got %s %v %v", tt.t, tt.kind, tt.before, tt.events, kind, before, events)
+		}
+	}
+}
+
+func TestTriggers(t *testing.T) {
+	const sqlSchema = `
+		CREATE TABLE TEST (ID INT, NAME VARCHAR(20));
+		CREATE GENERATOR TEST_SEQ;
+		SET TERM ^ ;
+		CREATE TRIGGER TEST_INSERT FOR TEST ACTIVE BEFORE INSERT POSITION 5 AS
+		BEGIN
+			IF (NEW.ID IS NULL) THEN
+				NEW.ID = CAST(GEN_ID(TEST_SEQ, 1) AS INT);
+		END^
+		CREATE TRIGGER TEST_AUDIT FOR TEST INACTIVE AFTER UPDATE OR DELETE AS
+		BEGIN
+		END^
+		SET TERM ; ^`
+
+	db, err := sql.Open("firebirdsql_createdb", "sysdba:masterkey@localhost:3050/tmp/fbx_test_triggers.fdb")
+	if err != nil {
+		t.Fatalf("Error creating database: %s", err)
+	}
+	defer db.Close()
+
+	err = ExecScript(db, sqlSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	triggers, err := Triggers(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var user []*Trigger
+	for _, trigger := range triggers {
+		if !trigger.System {
+			user = append(user, trigger)
+		}
+	}
+	if len(user) != 2 {
+		t.Fatalf("Expected %d user triggers, got %d", 2, len(user))
+	}
+
+	audit, insert := user[0], user[1]
+	if audit.Name != "TEST_AUDIT" || audit.TableName != "TEST" || audit.Active || audit.Before {
+		t.Errorf("Unexpected trigger %#v", audit)
+	}
+	if !reflect.DeepEqual([]string{"UPDATE", "DELETE"}, audit.Events) {
+		t.Errorf("Expected events [UPDATE DELETE], got %v", audit.Events)
+	}
+	if insert.Name != "TEST_INSERT" || !insert.Active || !insert.Before || insert.Position != 5 || insert.Kind != "DML" {
+		t.Errorf("Unexpected trigger %#v", insert)
+	}
+	if !insert.Source.Valid {
+		t.Error("Expected source")
+	}
+}
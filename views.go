@@ -0,0 +1,222 @@
+package fbx
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// View describes a view. Relations lists the base relations it selects from
+// and BaseColumns maps each view column taken directly from a base relation
+// to that relation's column.
+type View struct {
+	Name        string
+	Source      sql.NullString
+	Columns     []*Column
+	Relations   []string
+	BaseColumns map[string]ColumnRef
+	// Updatable is a heuristic: a view with triggers is updatable, as is a
+	// view over a single relation whose outermost SELECT has no DISTINCT,
+	// aggregate function, GROUP BY, HAVING, UNION, FIRST, SKIP, ROWS, OFFSET
+	// or FETCH. String literals, comments and parenthesized subqueries are
+	// ignored. It does not check that the view exposes every NOT NULL column
+	// of the relation without a default, so an INSERT may still fail.
+	Updatable bool
+}
+
+var readOnlyViewPattern = regexp.MustCompile(`(?is)^\s*SELECT\s+(FIRST|SKIP)\b|` +
+	`\b(DISTINCT|GROUP\s+BY|HAVING|UNION|ROWS|OFFSET|FETCH)\b|\b(COUNT|SUM|AVG|MIN|MAX|LIST)\s*\(`)
+
+func Views(db Querier) (views []*View, err error) {
+	return ViewsContext(context.Background(), db)
+}
+
+func ViewsContext(ctx context.Context, db Querier) (views []*View, err error) {
+	const query = `
+		SELECT v.rdb$relation_name, v.rdb$view_source,
+			(SELECT COUNT(*) FROM rdb$triggers t WHERE t.rdb$relation_name = v.rdb$relation_name)
+		FROM rdb$relations v
+		WHERE (v.rdb$system_flag <> 1 OR v.rdb$system_flag IS NULL) AND NOT v.rdb$view_blr IS NULL
+		ORDER BY v.rdb$relation_name`
+
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	byName := make(map[string]*View)
+	triggers := make(map[string]bool)
+	for rows.Next() {
+		var v View
+		var triggerCount int
+		if err = rows.Scan(&v.Name, &v.Source, &triggerCount); err != nil {
+			return
+		}
+		v.Name = strings.TrimRightFunc(v.Name, unicode.IsSpace)
+		if v.Source.Valid {
+			v.Source.String = strings.TrimSpace(v.Source.String)
+		}
+		v.BaseColumns = make(map[string]ColumnRef)
+		triggers[v.Name] = triggerCount > 0
+		byName[v.Name] = &v
+		views = append(views, &v)
+	}
+	if err = rows.Err(); err != nil {
+		return
+	}
+	rows.Close()
+	if len(views) == 0 {
+		return
+	}
+
+	const relationsQuery = `
+		SELECT vr.rdb$view_name, vr.rdb$relation_name
+		FROM rdb$view_relations vr
+		ORDER BY vr.rdb$view_name, vr.rdb$view_context`
+
+	if rows, err = db.QueryContext(ctx, relationsQuery); err != nil {
+		return
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var viewName, relationName string
+		if err = rows.Scan(&viewName, &relationName); err != nil {
+			return
+		}
+		v, ok := byName[strings.TrimRightFunc(viewName, unicode.IsSpace)]
+		if !ok {
+			continue
+		}
+		relationName = strings.TrimRightFunc(relationName, unicode.IsSpace)
+		if !containsString(v.Relations, relationName) {
+			v.Relations = append(v.Relations, relationName)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return
+	}
+	rows.Close()
+
+	const lineageQuery = `
+		SELECT r.rdb$relation_name, r.rdb$field_name, vr.rdb$relation_name, r.rdb$base_field
+		FROM rdb$relation_fields r
+		JOIN rdb$view_relations vr ON r.rdb$relation_name = vr.rdb$view_name
+			AND r.rdb$view_context = vr.rdb$view_context
+		WHERE r.rdb$base_field IS NOT NULL`
+
+	if rows, err = db.QueryContext(ctx, lineageQuery); err != nil {
+		return
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var viewName, fieldName string
+		var base ColumnRef
+		if err = rows.Scan(&viewName, &fieldName, &base.TableName, &base.Name); err != nil {
+			return
+		}
+		if v, ok := byName[strings.TrimRightFunc(viewName, unicode.IsSpace)]; ok {
+			base.TableName = strings.TrimRightFunc(base.TableName, unicode.IsSpace)
+			base.Name = strings.TrimRightFunc(base.Name, unicode.IsSpace)
+			v.BaseColumns[strings.TrimRightFunc(fieldName, unicode.IsSpace)] = base
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return
+	}
+	rows.Close()
+
+	columns, err := queryColumns(ctx, db, `WHERE r.rdb$relation_name IN (
+		SELECT v.rdb$relation_name FROM rdb$relations v
+		WHERE (v.rdb$system_flag <> 1 OR v.rdb$system_flag IS NULL) AND NOT v.rdb$view_blr IS NULL)`)
+	if err != nil {
+		return
+	}
+	for _, v := range views {
+		v.Columns = columns[v.Name]
+		v.Updatable = triggers[v.Name] ||
+			(len(v.Relations) == 1 && !readOnlyViewPattern.MatchString(outermostSelect(v.Source.String)))
+	}
+	return
+}
+
+// outermostSelect blanks out string literals, quoted identifiers and
+// comments in a view's source and empties its parentheses, leaving the
+// clauses of the outermost SELECT.
+func outermostSelect(source string) string {
+	var b strings.Builder
+	depth := 0
+	emit := func(c byte) {
+		if depth == 0 {
+			b.WriteByte(c)
+		}
+	}
+	for i := 0; i < len(source); i++ {
+		c := source[i]
+		switch {
+		case (c == 'q' || c == 'Q') && i+2 < len(source) && source[i+1] == '\'' && (i == 0 || !isIdentByte(source[i-1])):
+			end := source[i+2]
+			switch end {
+			case '(':
+				end = ')'
+			case '[':
+				end = ']'
+			case '{':
+				end = '}'
+			case '<':
+				end = '>'
+			}
+			i += 3
+			for i < len(source) && !(source[i] == end && i+1 < len(source) && source[i+1] == '\'') {
+				i++
+			}
+			i++
+			emit(' ')
+		case c == '\'' || c == '"':
+			for i++; i < len(source); i++ {
+				if source[i] == c {
+					if i+1 < len(source) && source[i+1] == c {
+						i++
+						continue
+					}
+					break
+				}
+			}
+			emit(' ')
+		case c == '-' && i+1 < len(source) && source[i+1] == '-':
+			for i+1 < len(source) && source[i+1] != '\n' {
+				i++
+			}
+			emit(' ')
+		case c == '/' && i+1 < len(source) && source[i+1] == '*':
+			if end := strings.Index(source[i+2:], "*/"); end >= 0 {
+				i += end + 3
+			} else {
+				i = len(source)
+			}
+			emit(' ')
+		case c == '(':
+			emit(c)
+			depth++
+		case c == ')':
+			if depth > 0 {
+				depth--
+			}
+			emit(c)
+		default:
+			emit(c)
+		}
+	}
+	return b.String()
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+package fbx
+
+import (
+	"database/sql"
+	_ "github.com/rowland/firebirdsql"
+	"reflect"
+	"testing"
+)
+
+func TestViews(t *testing.T) {
+	const sqlSchema = `
+		CREATE TABLE TEST1 (ID INT, NAME1 VARCHAR(10));
+		CREATE TABLE TEST2 (ID INT, NAME2 VARCHAR(10));
+		CREATE VIEW VIEW1 AS SELECT TEST1.ID, TEST1.NAME1, TEST2.NAME2 FROM TEST1 JOIN TEST2 ON TEST1.ID = TEST2.ID;
+		CREATE VIEW VIEW2 (KEY_ID, LABEL) AS SELECT ID, NAME1 FROM TEST1;
+		CREATE VIEW VIEW3 AS SELECT COUNT(*) N FROM TEST2;
+		CREATE VIEW VIEW4 AS SELECT ID, NAME1 "COUNT" FROM TEST1 /* no DISTINCT */
+			WHERE NAME1 <> 'GROUP BY' AND ID IN (SELECT MAX(ID) FROM TEST2);
+		CREATE VIEW VIEW5 AS SELECT FIRST 10 ID, NAME1 FROM TEST1;`
+
+	db, err := sql.Open("firebirdsql_createdb", "sysdba:masterkey@localhost:3050/tmp/fbx_test_views.fdb")
+	if err != nil {
+		t.Fatalf("Error creating database: %s", err)
+	}
+	defer db.Close()
+
+	err = ExecScript(db, sqlSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	views, err := Views(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(views) != 5 {
+		t.Fatalf("Expected %d views, got %d", 5, len(views))
+	}
+
+	view1, view2, view3 := views[0], views[1], views[2]
+	if !reflect.DeepEqual([]string{"TEST1", "TEST2"}, view1.Relations) {
+		t.Errorf("Expected relations [TEST1 TEST2], got %v", view1.Relations)
+	}
+	if view1.Updatable {
+		t.Error("Expected VIEW1 to be read-only")
+	}
+	if len(view1.Columns) != 3 || view1.Columns[2].Name != "NAME2" || view1.Columns[2].SqlType != "VARCHAR" {
+		t.Errorf("Unexpected VIEW1 columns %v", view1.Columns)
+	}
+	if exp := (ColumnRef{"TEST2", "NAME2"}); view1.BaseColumns["NAME2"] != exp {
+		t.Errorf("Expected NAME2 from %v, got %v", exp, view1.BaseColumns["NAME2"])
+	}
+
+	if !view2.Updatable {
+		t.Error("Expected VIEW2 to be updatable")
+	}
+	if view2.Source.String != "SELECT ID, NAME1 FROM TEST1" {
+		t.Errorf("Expected Source <%s>, got <%s>", "SELECT ID, NAME1 FROM TEST1", view2.Source.String)
+	}
+	expBase := map[string]ColumnRef{"KEY_ID": {"TEST1", "ID"}, "LABEL": {"TEST1", "NAME1"}}
+	if !reflect.DeepEqual(expBase, view2.BaseColumns) {
+		t.Errorf("Expected %v, got %v", expBase, view2.BaseColumns)
+	}
+
+	if view3.Updatable {
+		t.Error("Expected VIEW3 to be read-only")
+	}
+	if len(view3.BaseColumns) != 0 {
+		t.Errorf("Expected no base columns, got %v", view3.BaseColumns)
+	}
+
+	if !views[3].Updatable {
+		t.Error("Expected VIEW4 to be updatable")
+	}
+	if views[4].Updatable {
+		t.Error("Expected VIEW5 to be read-only")
+	}
+}
+
+func TestOutermostSelect(t *testing.T) {
+	tests := []struct {
+		source   string
+		exp      string
+		readOnly bool
+	}{
+		{"SELECT ID, NAME FROM T", "SELECT ID, NAME FROM T", false},
+		{"SELECT COUNT(*) N FROM T", "SELECT COUNT() N FROM T", true},
+		{"SELECT ID, 'DISTINCT' FROM T", "SELECT ID,   FROM T", false},
+		{"SELECT ID, q'{it's MIN(}' FROM T", "SELECT ID,   FROM T", false},
+		{`SELECT ID "MAX" FROM T`, "SELECT ID   FROM T", false},
+		{"SELECT ID -- UNION\nFROM T", "SELECT ID  \nFROM T", false},
+		{"SELECT ID /* GROUP BY */ FROM T", "SELECT ID   FROM T", false},
+		{"SELECT ID FROM T WHERE ID = (SELECT MAX(ID) FROM U)", "SELECT ID FROM T WHERE ID = ()", false},
+		{"SELECT DISTINCT ID FROM T", "SELECT DISTINCT ID FROM T", true},
+		{"SELECT ID FROM T GROUP  BY ID", "SELECT ID FROM T GROUP  BY ID", true},
+		{"select first 5 id from t", "select first 5 id from t", true},
+		{"SELECT SKIP 5 ID FROM T", "SELECT SKIP 5 ID FROM T", true},
+		{"SELECT ID FROM T ROWS 10", "SELECT ID FROM T ROWS 10", true},
+		{"SELECT ID FROM T OFFSET 1 ROW", "SELECT ID FROM T OFFSET 1 ROW", true},
+		{"SELECT FIRST_NAME, MINIMUM FROM T", "SELECT FIRST_NAME, MINIMUM FROM T", false},
+	}
+	for _, tt := range tests {
+		got := outermostSelect(tt.source)
+		if got != tt.exp {
+			t.Errorf("%q: expected <%q>, got <%q>", tt.source, tt.exp, got)
+		}
+		if readOnly := readOnlyViewPattern.MatchString(got); readOnly != tt.readOnly {
+			t.Errorf("%q: expected read-only <%v>, got <%v>", tt.source, tt.readOnly, readOnly)
+		}
+	}
+}